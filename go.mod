@@ -1,6 +1,23 @@
-module kz-domain-monitor
+module github.com/Kravets1996/kz-domain-monitor
 
-go 1.24
+go 1.25.0
 
 require github.com/joho/godotenv v1.5.1
-require github.com/fynelabs/selfupdate v0.2.1
+
+require (
+	github.com/eclipse/paho.mqtt.golang v1.5.1
+	github.com/fynelabs/selfupdate v0.2.1
+	github.com/nats-io/nats.go v1.53.1
+	github.com/rabbitmq/amqp091-go v1.15.0
+)
+
+require (
+	github.com/gorilla/websocket v1.5.3 // indirect
+	github.com/klauspost/compress v1.18.5 // indirect
+	github.com/nats-io/nkeys v0.4.15 // indirect
+	github.com/nats-io/nuid v1.0.1 // indirect
+	golang.org/x/crypto v0.49.0 // indirect
+	golang.org/x/net v0.51.0 // indirect
+	golang.org/x/sync v0.17.0 // indirect
+	golang.org/x/sys v0.42.0 // indirect
+)
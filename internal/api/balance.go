@@ -0,0 +1,17 @@
+package api
+
+import (
+	"context"
+	"github.com/Kravets1996/kz-domain-monitor/internal/config"
+	"github.com/Kravets1996/kz-domain-monitor/pkg/kzdomain"
+)
+
+// FetchAccount fetches the balance of the ps.kz account and its domains with the auto-renewal settings.
+func FetchAccount(ctx context.Context, cfg config.Config) (kzdomain.Account, error) {
+	return accountProvider(cfg).Account(ctx)
+}
+
+// Renewals returns the auto-renewals of the account due within the configured thresholds.
+func Renewals(account kzdomain.Account, cfg config.Config) kzdomain.Renewals {
+	return account.Renewals(ConfigPolicy(cfg))
+}
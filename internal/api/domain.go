@@ -1,36 +1,54 @@
 package api
 
 import (
-	"fmt"
-	"kz-domain-monitor/internal/config"
+	"github.com/Kravets1996/kz-domain-monitor/internal/i18n"
+	"github.com/Kravets1996/kz-domain-monitor/pkg/kzdomain"
 	"time"
 )
 
+// Domain is a checked domain. It has the same fields as kzdomain.Result and converts to it.
 type Domain struct {
 	Name           string
 	IsAvailable    bool
 	ExpirationDate *time.Time
-	Error          error
+	// Registrar is the name of the sponsoring registrar, when the provider reports it.
+	Registrar string
+	Error     error
+	// Policy is the rules the domain is evaluated against.
+	Policy Policy
 }
 
+// Severity describes how urgent the domain state is. Higher values are more severe.
+type Severity = kzdomain.Severity
+
+const (
+	SeverityOk       = kzdomain.SeverityOk
+	SeverityWarning  = kzdomain.SeverityWarning
+	SeverityError    = kzdomain.SeverityError
+	SeverityCritical = kzdomain.SeverityCritical
+)
+
+// GetDaysToExpire returns the number of calendar days in the policy time zone until the expiration date:
+// 0 on the day of expiration and negative after it, regardless of the time of the check.
 func (domain Domain) GetDaysToExpire() int64 {
-	diff := time.Until(*domain.ExpirationDate)
-	days := int64(diff.Hours() / 24)
+	return kzdomain.Result(domain).DaysLeft()
+}
 
-	return days
+// ExpiresAt returns the expiration date in the policy time zone.
+func (domain Domain) ExpiresAt() time.Time {
+	return kzdomain.Result(domain).ExpiresAt()
 }
 
 func (domain Domain) isCloseToExpire() bool {
-	cfg := config.GetConfig()
-
-	return domain.GetDaysToExpire() <= cfg.DaysToExpire
+	return kzdomain.Result(domain).IsCloseToExpire()
 }
 
 func (domain Domain) isExpired() bool {
-	return domain.GetDaysToExpire() < 0
+	return kzdomain.Result(domain).IsExpired()
 }
 
-func (domain Domain) getIcon() string {
+// GetIcon returns the status icon used in notification messages.
+func (domain Domain) GetIcon() string {
 	if domain.IsAvailable {
 		return "❌"
 	}
@@ -46,12 +64,14 @@ func (domain Domain) getIcon() string {
 	return "✅"
 }
 
+// IsOk reports whether the severity of the domain is SeverityOk.
 func (domain Domain) IsOk() bool {
-	if domain.Error != nil || domain.ExpirationDate == nil {
-		return false
-	}
+	return kzdomain.Result(domain).IsOk()
+}
 
-	return !domain.IsAvailable && !domain.isCloseToExpire()
+// GetSeverity returns the severity of the domain status by its policy.
+func (domain Domain) GetSeverity() Severity {
+	return kzdomain.Result(domain).Severity()
 }
 
 func (domain Domain) ShouldSend() bool {
@@ -61,21 +81,39 @@ func (domain Domain) ShouldSend() bool {
 	}
 
 	// Успешные проверки - только если нет флага OnlyErrors
-	return !config.GetConfig().SendOnlyErrors
+	return !domain.Policy.OnlyErrors
 }
 
+// GetMessage returns the domain state in the default language (LANGUAGE).
 func (domain Domain) GetMessage() string {
+	return domain.Message(i18n.Printer{})
+}
+
+// Message returns the domain state in the printer language, e.g. "⚠️ 3 дня - example.kz".
+func (domain Domain) Message(p i18n.Printer) string {
 	if domain.Error != nil {
 		return "❗️ " + domain.Error.Error()
 	}
 
-	if domain.ExpirationDate == nil {
-		return fmt.Sprintf("❗️ Дата истечения оплаты домена %s недоступна", domain.Name)
+	if domain.IsAvailable {
+		return p.T("domain.available", domain.Name)
 	}
 
-	if domain.IsAvailable {
-		return "❌ Домен доступен для регистрации: " + domain.Name
+	if domain.ExpirationDate == nil {
+		return p.T("domain.no_date", domain.Name)
 	}
 
-	return fmt.Sprintf("%s %d дней - %s", domain.getIcon(), domain.GetDaysToExpire(), domain.Name)
+	return domain.GetIcon() + " " + domain.Days(p) + " - " + domain.Name
+}
+
+// Days describes the time left until expiration, e.g. "3 дня", "истекает сегодня" or "истёк 5 дней назад".
+func (domain Domain) Days(p i18n.Printer) string {
+	switch days := domain.GetDaysToExpire(); {
+	case days == 0:
+		return p.T("days.today")
+	case days < 0:
+		return p.N("expired", -days)
+	default:
+		return p.N("days", days)
+	}
 }
@@ -1,19 +1,10 @@
 package api
 
 import (
-	"kz-domain-monitor/internal/config"
-	"os"
 	"testing"
 	"time"
 )
 
-func TestMain(m *testing.M) {
-	config.Configuration = config.Config{
-		DaysToExpire: 15,
-	}
-	os.Exit(m.Run())
-}
-
 func TestDomain_IsOk_OK(t *testing.T) {
 	domain := getBasicDomain()
 
@@ -103,7 +94,7 @@ func TestDomain_GetMessage_Expired(t *testing.T) {
 	domain.ExpirationDate = days(-10)
 
 	message := domain.GetMessage()
-	exampleMessage := "❗️ -10 дней - example.kz"
+	exampleMessage := "❗️ истёк 10 дней назад - example.kz"
 
 	if message != exampleMessage {
 		t.Fatal("wrong message", message, exampleMessage)
@@ -128,14 +119,76 @@ func getBasicDomain() Domain {
 		Name:           "example.kz",
 		IsAvailable:    false,
 		ExpirationDate: days(90),
+		Policy:         Policy{DaysToExpire: 15},
 	}
 }
 
-func days(n time.Duration) *time.Time {
-	offset := time.Hour * 12
-	if n < 0 {
-		offset = -offset
-	}
-	t := time.Now().Add(time.Hour*24*n + offset)
+func days(n int) *time.Time {
+	t := time.Now().AddDate(0, 0, n)
 	return &t
 }
+
+func TestDomain_GetSeverity(t *testing.T) {
+	domain := getBasicDomain()
+	if domain.GetSeverity() != SeverityOk {
+		t.Error("expected SeverityOk", domain)
+	}
+
+	domain.ExpirationDate = days(10)
+	if domain.GetSeverity() != SeverityWarning {
+		t.Error("expected SeverityWarning", domain)
+	}
+
+	domain.ExpirationDate = days(-10)
+	if domain.GetSeverity() != SeverityCritical {
+		t.Error("expected SeverityCritical", domain)
+	}
+
+	domain.ExpirationDate = nil
+	if domain.GetSeverity() != SeverityError {
+		t.Error("expected SeverityError", domain)
+	}
+}
+
+func TestDomain_GetMessage_AvailableNoDate(t *testing.T) {
+	domain := Domain{Name: "example.kz", IsAvailable: true}
+
+	message := domain.GetMessage()
+	exampleMessage := "❌ Домен доступен для регистрации: example.kz"
+
+	if message != exampleMessage {
+		t.Fatal("wrong message", message, exampleMessage)
+	}
+
+	if domain.GetSeverity() != SeverityCritical {
+		t.Error("available domain should be critical", domain)
+	}
+}
+
+func TestDomain_GetDaysToExpire_CalendarDays(t *testing.T) {
+	location := time.FixedZone("UTC+5", 5*60*60)
+	now := time.Date(2025, 3, 1, 18, 30, 0, 0, location)
+	policy := Policy{DaysToExpire: 15, Location: location, Now: func() time.Time { return now }}
+
+	tests := []struct {
+		expiration time.Time
+		days       int64
+		message    string
+	}{
+		{time.Date(2025, 3, 1, 23, 59, 0, 0, location), 0, "⚠️ истекает сегодня - example.kz"},
+		{time.Date(2025, 3, 1, 6, 47, 0, 0, time.UTC), 0, "⚠️ истекает сегодня - example.kz"},
+		{time.Date(2025, 3, 1, 19, 30, 0, 0, time.UTC), 1, "⚠️ 1 день - example.kz"},
+		{time.Date(2025, 2, 28, 23, 59, 0, 0, location), -1, "❗️ истёк 1 день назад - example.kz"},
+	}
+
+	for _, test := range tests {
+		domain := Domain{Name: "example.kz", ExpirationDate: &test.expiration, Policy: policy}
+
+		if days := domain.GetDaysToExpire(); days != test.days {
+			t.Errorf("expected %d days for %s, got %d", test.days, test.expiration, days)
+		}
+		if message := domain.GetMessage(); message != test.message {
+			t.Errorf("unexpected message for %s: %q", test.expiration, message)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"github.com/Kravets1996/kz-domain-monitor/internal/config"
+	"github.com/Kravets1996/kz-domain-monitor/internal/state"
+	"github.com/Kravets1996/kz-domain-monitor/pkg/kzdomain"
+	"slices"
+	"strings"
+)
+
+// ImportDomains returns the domains of the ps.kz account matching PS_IMPORT_INCLUDE and PS_IMPORT_EXCLUDE
+// that are missing from the configured domains.
+func ImportDomains(ctx context.Context, cfg config.Config) ([]string, error) {
+	account, err := accountProvider(cfg).AccountDomains(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return missingDomains(account, cfg), nil
+}
+
+// ImportAccountDomains is ImportDomains for an account that is already fetched.
+func ImportAccountDomains(account kzdomain.Account, cfg config.Config) []string {
+	names := make([]string, 0, len(account.Domains))
+	for _, domain := range account.Domains {
+		names = append(names, domain.Name)
+	}
+
+	return missingDomains(names, cfg)
+}
+
+func accountProvider(cfg config.Config) *kzdomain.PsKzProvider {
+	return &kzdomain.PsKzProvider{Token: cfg.PSApiToken, ErrorLog: errorLogFile(cfg.ErrorLogFile)}
+}
+
+// NotImported returns the domains with open alerts in STATE_FILE that are missing from the configuration.
+// They were imported by earlier runs, so when the import fails they are returned as not checked, with an error
+// wrapping ErrNotChecked and importErr. Integrations keep their alerts instead of resolving them.
+func NotImported(cfg config.Config, importErr error) ([]Domain, error) {
+	alerts, err := state.Load(cfg.StateFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for integration := range alerts.Alerts {
+		names = append(names, alerts.Domains(integration)...)
+	}
+	slices.Sort(names)
+
+	policy := ConfigPolicy(cfg)
+	var domains []Domain
+	for _, name := range missingDomains(names, cfg) {
+		domains = append(domains, Domain{Name: name, Error: fmt.Errorf("%s %w: %w", name, ErrNotChecked, importErr), Policy: policy(name)})
+	}
+
+	return domains, nil
+}
+
+func missingDomains(account []string, cfg config.Config) []string {
+	configured := make(map[string]bool, len(cfg.DomainList))
+	for _, name := range cfg.DomainList {
+		configured[strings.ToLower(strings.TrimSpace(name))] = true
+	}
+
+	var missing []string
+	for _, name := range account {
+		name = strings.ToLower(name)
+		if !configured[name] && cfg.Import.Matches(name) {
+			configured[name] = true
+			missing = append(missing, name)
+		}
+	}
+
+	return missing
+}
@@ -0,0 +1,56 @@
+package api
+
+import (
+	"errors"
+	"github.com/Kravets1996/kz-domain-monitor/internal/config"
+	"github.com/Kravets1996/kz-domain-monitor/internal/state"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMissingDomains(t *testing.T) {
+	cfg := config.Config{
+		DomainList: []string{"example.kz", " Shop.kz"},
+		Import:     config.ImportConfig{Enabled: true, Exclude: []string{"test-*"}},
+	}
+
+	missing := missingDomains([]string{"example.kz", "shop.kz", "EGOV.kz", "test-example.kz", "egov.kz"}, cfg)
+
+	if strings.Join(missing, ",") != "egov.kz" {
+		t.Errorf("unexpected missing domains: %v", missing)
+	}
+}
+
+func TestNotImported(t *testing.T) {
+	cfg := config.Config{
+		DomainList: []string{"example.kz"},
+		Import:     config.ImportConfig{Enabled: true},
+		StateFile:  filepath.Join(t.TempDir(), "state.json"),
+	}
+
+	alerts, err := state.Load(cfg.StateFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	alerts.Set("pagerduty", "example.kz", state.Alert{UpdatedAt: time.Now()})
+	alerts.Set("pagerduty", "egov.kz", state.Alert{UpdatedAt: time.Now()})
+	alerts.Set("opsgenie", "egov.kz", state.Alert{ID: "1", UpdatedAt: time.Now()})
+	if err := alerts.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	importErr := errors.New("ps.kz is unavailable")
+	domains, err := NotImported(cfg, importErr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(domains) != 1 || domains[0].Name != "egov.kz" {
+		t.Fatalf("unexpected domains: %v", domains)
+	}
+	if !errors.Is(domains[0].Error, ErrNotChecked) || !errors.Is(domains[0].Error, importErr) || domains[0].GetSeverity() != SeverityError {
+		t.Errorf("expected egov.kz to be reported as not checked, got %v", domains[0].Error)
+	}
+}
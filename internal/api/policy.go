@@ -0,0 +1,22 @@
+package api
+
+import (
+	"github.com/Kravets1996/kz-domain-monitor/internal/config"
+	"github.com/Kravets1996/kz-domain-monitor/pkg/kzdomain"
+)
+
+// Policy holds the rules a domain is evaluated against.
+type Policy = kzdomain.Policy
+
+// ConfigPolicy returns the policy of every domain from cfg: DAYS_TO_EXPIRE or the "daysToExpire" of its
+// group, SEND_ONLY_ERRORS, STATUS_SEVERITY and TIMEZONE.
+func ConfigPolicy(cfg config.Config) func(name string) Policy {
+	return func(name string) Policy {
+		return Policy{
+			DaysToExpire: cfg.DaysToExpireFor(name),
+			OnlyErrors:   cfg.SendOnlyErrors,
+			Severities:   cfg.StatusSeverities,
+			Location:     cfg.Location(),
+		}
+	}
+}
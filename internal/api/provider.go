@@ -1,23 +1,86 @@
 package api
 
-import "kz-domain-monitor/internal/config"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Kravets1996/kz-domain-monitor/internal/config"
+	"github.com/Kravets1996/kz-domain-monitor/pkg/kzdomain"
+	"log"
+	"os"
+	"time"
+)
 
-// Provider defines the interface for domain info providers.
-type Provider interface {
-	GetDomainInfo(domainName string) Domain
-}
+// ErrNotChecked is wrapped by the error of domains left unchecked because the check was aborted
+// or the import of the ps.kz account domains failed.
+var ErrNotChecked = errors.New("not checked")
 
 // NewProvider returns the appropriate provider based on configuration.
-func NewProvider(cfg config.Config) Provider {
+func NewProvider(cfg config.Config) kzdomain.Provider {
+	errorLog := errorLogFile(cfg.ErrorLogFile)
+
 	switch cfg.DomainProvider {
 	case "rdap":
-		return &RDAPProvider{}
+		return &kzdomain.RDAPProvider{ErrorLog: errorLog}
 	default:
-		return &PsKzProvider{}
+		return &kzdomain.PsKzProvider{Token: cfg.PSApiToken, BatchSize: cfg.PSBatchSize, ErrorLog: errorLog}
 	}
 }
 
-// GetDomainInfo fetches domain information using the configured provider.
-func GetDomainInfo(domainName string) Domain {
-	return NewProvider(config.GetConfig()).GetDomainInfo(domainName)
+// CheckDomains fetches information for every domain with the configured provider, pausing between requests,
+// and evaluates it against the configured policy. The check is limited by RUN_TIMEOUT. When it expires or ctx
+// is done, domains that were not checked are returned with an error wrapping ErrNotChecked and the context error.
+func CheckDomains(ctx context.Context, cfg config.Config, domainNames []string) ([]Domain, error) {
+	if cfg.RunTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.RunTimeout)
+		defer cancel()
+	}
+
+	policy := ConfigPolicy(cfg)
+	checker := kzdomain.New(NewProvider(cfg),
+		kzdomain.WithDelay(cfg.RequestDelay),
+		kzdomain.WithPolicy(policy),
+		kzdomain.OnResult(func(result kzdomain.Result) {
+			log.Println(Domain(result).GetMessage())
+		}),
+	)
+
+	results, err := checker.Check(ctx, domainNames)
+
+	domains := make([]Domain, 0, len(domainNames))
+	for _, result := range results {
+		domains = append(domains, Domain(result))
+	}
+
+	if err != nil {
+		for _, name := range domainNames[len(results):] {
+			domains = append(domains, Domain{Name: name, Error: fmt.Errorf("%s %w: %w", name, ErrNotChecked, err), Policy: policy(name)})
+		}
+	}
+
+	return domains, err
+}
+
+// errorLogFile appends failed provider responses to path, unless it is empty.
+func errorLogFile(path string) kzdomain.ErrorLog {
+	if path == "" {
+		return nil
+	}
+
+	return func(errorMsg string) {
+		f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			log.Printf("Failed to open log file: %v", err)
+			return
+		}
+		defer f.Close()
+
+		timestamp := time.Now().Format("2006-01-02 15:04:05")
+		logEntry := fmt.Sprintf("[%s] %s\n", timestamp, errorMsg)
+
+		if _, err := f.WriteString(logEntry); err != nil {
+			log.Printf("Failed to write to log file: %v", err)
+		}
+	}
 }
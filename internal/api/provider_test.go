@@ -0,0 +1,56 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"github.com/Kravets1996/kz-domain-monitor/internal/config"
+	"github.com/Kravets1996/kz-domain-monitor/pkg/kzdomain"
+	"testing"
+)
+
+func TestNewProvider_RDAP(t *testing.T) {
+	cfg := config.Config{DomainProvider: "rdap"}
+	provider := NewProvider(cfg)
+	if _, ok := provider.(*kzdomain.RDAPProvider); !ok {
+		t.Error("expected RDAPProvider for 'rdap' config")
+	}
+}
+
+func TestNewProvider_PsKz(t *testing.T) {
+	cfg := config.Config{DomainProvider: "pskz"}
+	provider := NewProvider(cfg)
+	if _, ok := provider.(*kzdomain.PsKzProvider); !ok {
+		t.Error("expected PsKzProvider for 'pskz' config")
+	}
+}
+
+func TestNewProvider_Default(t *testing.T) {
+	cfg := config.Config{DomainProvider: ""}
+	provider := NewProvider(cfg)
+	if _, ok := provider.(*kzdomain.PsKzProvider); !ok {
+		t.Error("expected PsKzProvider as default when DomainProvider is empty")
+	}
+}
+
+func TestCheckDomains_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	cfg := config.Config{DomainProvider: "rdap", DaysToExpire: 15}
+	domains, err := CheckDomains(ctx, cfg, []string{"example.kz", "egov.kz"})
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if len(domains) != 2 {
+		t.Fatalf("expected every domain to be reported, got %v", domains)
+	}
+	for _, domain := range domains {
+		if !errors.Is(domain.Error, ErrNotChecked) || domain.GetSeverity() != SeverityError {
+			t.Errorf("expected %s to be reported as not checked, got %v", domain.Name, domain.Error)
+		}
+	}
+	if domains[1].Error.Error() != "egov.kz not checked: context canceled" {
+		t.Errorf("unexpected error: %q", domains[1].Error)
+	}
+}
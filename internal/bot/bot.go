@@ -0,0 +1,212 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/Kravets1996/kz-domain-monitor/internal/config"
+	"github.com/Kravets1996/kz-domain-monitor/internal/i18n"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// pollTimeout is the long polling timeout passed to getUpdates.
+const pollTimeout = 30
+
+// Bot answers Telegram commands from allowed chats using long polling.
+type Bot struct {
+	APIURL string // defaults to "https://api.telegram.org"
+
+	token   string
+	allowed map[string]bool
+	client  http.Client
+	printer i18n.Printer
+
+	// checking holds a token while /check or /status runs, see runCheck.
+	checking chan struct{}
+	checks   sync.WaitGroup
+}
+
+type updatesResponse struct {
+	OK          bool     `json:"ok"`
+	Description string   `json:"description"`
+	Result      []update `json:"result"`
+}
+
+type update struct {
+	UpdateID int64    `json:"update_id"`
+	Message  *message `json:"message"`
+}
+
+type message struct {
+	Chat struct {
+		ID int64 `json:"id"`
+	} `json:"chat"`
+	Text string `json:"text"`
+}
+
+// New creates a bot from the Telegram configuration.
+func New(cfg config.Config) (*Bot, error) {
+	if cfg.Telegram.BotToken == "" {
+		return nil, errors.New("TELEGRAM_BOT_TOKEN is not set")
+	}
+	if len(cfg.Telegram.AllowedChatIDs) == 0 {
+		return nil, errors.New("TELEGRAM_ALLOWED_CHAT_IDS or TELEGRAM_CHAT_ID is not set")
+	}
+
+	allowed := make(map[string]bool, len(cfg.Telegram.AllowedChatIDs))
+	for _, chatID := range cfg.Telegram.AllowedChatIDs {
+		allowed[chatID] = true
+	}
+
+	return &Bot{
+		token:   cfg.Telegram.BotToken,
+		allowed: allowed,
+		client:  http.Client{Timeout: time.Second * (pollTimeout + 10)},
+		printer: i18n.New(cfg.LanguageFor("telegram")),
+
+		checking: make(chan struct{}, 1),
+	}, nil
+}
+
+func (b *Bot) apiURL() string {
+	if b.APIURL != "" {
+		return b.APIURL
+	}
+	return "https://api.telegram.org"
+}
+
+// Run polls Telegram for updates until the context is cancelled.
+func (b *Bot) Run(ctx context.Context) {
+	var offset int64
+
+	log.Println("Telegram bot started")
+
+	for ctx.Err() == nil {
+		updates, err := b.getUpdates(ctx, offset)
+		if err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+
+			log.Printf("Telegram getUpdates error: %s", err)
+
+			select {
+			case <-ctx.Done():
+			case <-time.After(time.Second * 5):
+			}
+			continue
+		}
+
+		for _, u := range updates {
+			offset = u.UpdateID + 1
+			b.handleUpdate(ctx, u)
+		}
+	}
+
+	b.checks.Wait()
+	log.Println("Telegram bot stopped")
+}
+
+func (b *Bot) getUpdates(ctx context.Context, offset int64) ([]update, error) {
+	query := url.Values{}
+	query.Set("offset", strconv.FormatInt(offset, 10))
+	query.Set("timeout", strconv.Itoa(pollTimeout))
+	query.Set("allowed_updates", `["message"]`)
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, b.apiURL()+"/bot"+b.token+"/getUpdates?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := b.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	var result updatesResponse
+	if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to parse getUpdates response: %w", err)
+	}
+
+	if !result.OK {
+		return nil, fmt.Errorf("telegram api error: %s", result.Description)
+	}
+
+	return result.Result, nil
+}
+
+func (b *Bot) handleUpdate(ctx context.Context, u update) {
+	if u.Message == nil || u.Message.Text == "" {
+		return
+	}
+
+	chatID := strconv.FormatInt(u.Message.Chat.ID, 10)
+
+	if !b.allowed[chatID] {
+		log.Printf("Ignoring message from chat %s: not in TELEGRAM_ALLOWED_CHAT_IDS", chatID)
+		return
+	}
+
+	fields := strings.Fields(u.Message.Text)
+	if len(fields) == 0 {
+		return
+	}
+	command, _, _ := strings.Cut(fields[0], "@")
+
+	reply := func(messages ...string) {
+		for _, text := range messages {
+			if err := b.sendMessage(chatID, text); err != nil {
+				log.Printf("Failed to reply to chat %s: %s", chatID, err)
+				return
+			}
+		}
+	}
+
+	b.handleCommand(ctx, strings.ToLower(command), fields[1:], reply)
+}
+
+// runCheck answers with the result of check in the background, so that a slow registrar does not stop
+// the bot from polling for updates. Only one check runs at a time; other requests are answered with bot.busy.
+func (b *Bot) runCheck(reply func(messages ...string), check func() []string) {
+	select {
+	case b.checking <- struct{}{}:
+	default:
+		reply(b.printer.T("bot.busy"))
+		return
+	}
+
+	b.checks.Add(1)
+	go func() {
+		defer b.checks.Done()
+		defer func() { <-b.checking }()
+
+		reply(check()...)
+	}()
+}
+
+func (b *Bot) sendMessage(chatID, text string) error {
+	data := url.Values{}
+	data.Set("chat_id", chatID)
+	data.Set("text", text)
+	data.Set("parse_mode", "HTML")
+
+	response, err := b.client.PostForm(b.apiURL()+"/bot"+b.token+"/sendMessage", data)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("telegram api error: %s", response.Status)
+	}
+
+	return nil
+}
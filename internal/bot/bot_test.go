@@ -0,0 +1,131 @@
+package bot
+
+import (
+	"context"
+	"github.com/Kravets1996/kz-domain-monitor/internal/config"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func newTestBot(t *testing.T) (*Bot, *[]string) {
+	var replies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/sendMessage") {
+			t.Errorf("unexpected request: %s", r.URL.Path)
+		}
+		replies = append(replies, r.FormValue("text"))
+	}))
+	t.Cleanup(server.Close)
+
+	b, err := New(config.Config{Telegram: config.TelegramConfig{BotToken: "token", AllowedChatIDs: []string{"42"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.APIURL = server.URL
+
+	return b, &replies
+}
+
+func command(chatID int64, text string) update {
+	msg := &message{Text: text}
+	msg.Chat.ID = chatID
+	return update{Message: msg}
+}
+
+func TestNew_NoAllowedChats(t *testing.T) {
+	if _, err := New(config.Config{Telegram: config.TelegramConfig{BotToken: "token"}}); err == nil {
+		t.Error("expected error without allowed chats")
+	}
+}
+
+func TestBot_IgnoresUnknownChat(t *testing.T) {
+	b, replies := newTestBot(t)
+
+	b.handleUpdate(context.Background(), command(7, "/help"))
+
+	if len(*replies) != 0 {
+		t.Errorf("bot should not reply to chats outside of the allowlist: %q", *replies)
+	}
+}
+
+func TestBot_IgnoresBlankMessage(t *testing.T) {
+	b, replies := newTestBot(t)
+
+	b.handleUpdate(context.Background(), command(42, " \n\t"))
+
+	if len(*replies) != 0 {
+		t.Errorf("bot should not reply to a blank message: %q", *replies)
+	}
+}
+
+func TestBot_CheckAlreadyRunning(t *testing.T) {
+	b, replies := newTestBot(t)
+	b.checking <- struct{}{}
+
+	b.handleUpdate(context.Background(), command(42, "/status"))
+
+	if len(*replies) != 1 || (*replies)[0] != b.printer.T("bot.busy") {
+		t.Errorf("expected the busy reply, got %q", *replies)
+	}
+}
+
+func TestBot_List(t *testing.T) {
+	b, replies := newTestBot(t)
+	config.Configuration = config.Config{
+		DomainList:   []string{"example.kz", "egov.kz"},
+		DomainGroups: []config.DomainGroup{{Title: "Сайты", Domains: []string{"example.kz", "egov.kz"}}},
+	}
+
+	b.handleUpdate(context.Background(), command(42, "/list@kz_domain_bot"))
+
+	if len(*replies) != 1 {
+		t.Fatalf("expected 1 reply, got %d", len(*replies))
+	}
+	if !strings.Contains((*replies)[0], "<b>Сайты:</b>\nexample.kz\negov.kz") {
+		t.Errorf("unexpected reply: %q", (*replies)[0])
+	}
+}
+
+func TestBot_AddRemove(t *testing.T) {
+	b, replies := newTestBot(t)
+
+	path := filepath.Join(t.TempDir(), "domains.json")
+	if err := os.WriteFile(path, []byte(`[{"title": "Сайты", "items": [{"domain": "example.kz"}]}]`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	config.Configuration = config.Config{DomainConfigFile: path}
+
+	b.handleUpdate(context.Background(), command(42, "/add EGOV.kz"))
+	b.handleUpdate(context.Background(), command(42, "/add egov.kz"))
+	b.handleUpdate(context.Background(), command(42, "/remove example.kz"))
+
+	expected := []string{
+		"✅ egov.kz добавлен в список",
+		"❗️ egov.kz: домен уже в списке",
+		"✅ example.kz удалён из списка",
+	}
+	for i, reply := range expected {
+		if (*replies)[i] != reply {
+			t.Errorf("expected %q, got %q", reply, (*replies)[i])
+		}
+	}
+
+	if list := config.GetConfig().DomainList; len(list) != 1 || list[0] != "egov.kz" {
+		t.Errorf("domain list was not reloaded: %v", list)
+	}
+}
+
+func TestBot_AddWithoutConfigFile(t *testing.T) {
+	b, replies := newTestBot(t)
+	config.Configuration = config.Config{DomainList: []string{"example.kz"}}
+
+	b.handleUpdate(context.Background(), command(42, "/add egov.kz"))
+
+	if !strings.Contains((*replies)[0], "DOMAIN_CONFIG_FILE") {
+		t.Errorf("unexpected reply: %q", (*replies)[0])
+	}
+}
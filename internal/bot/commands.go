@@ -0,0 +1,146 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"github.com/Kravets1996/kz-domain-monitor/internal/api"
+	"github.com/Kravets1996/kz-domain-monitor/internal/config"
+	"github.com/Kravets1996/kz-domain-monitor/internal/i18n"
+	"github.com/Kravets1996/kz-domain-monitor/internal/notification/channels"
+	"github.com/Kravets1996/kz-domain-monitor/internal/report"
+	"html"
+	"regexp"
+	"strings"
+)
+
+var domainNamePattern = regexp.MustCompile(`^[\p{L}\p{N}-]+(\.[\p{L}\p{N}-]+)+$`)
+
+func (b *Bot) handleCommand(ctx context.Context, command string, args []string, reply func(messages ...string)) {
+	p := b.printer
+
+	switch command {
+	case "/start", "/help":
+		reply(html.EscapeString(p.T("bot.help")))
+	case "/list":
+		reply(listDomains(p, config.GetConfig()))
+	case "/check":
+		b.runCheck(reply, func() []string { return checkDomains(ctx, p, args) })
+	case "/status":
+		b.runCheck(reply, func() []string { return status(ctx, p) })
+	case "/add":
+		reply(changeDomains(p, args, config.AddDomain, p.T("bot.added")))
+	case "/remove":
+		reply(changeDomains(p, args, config.RemoveDomain, p.T("bot.removed")))
+	default:
+		reply(p.T("bot.unknown") + "\n\n" + html.EscapeString(p.T("bot.help")))
+	}
+}
+
+func listDomains(p i18n.Printer, cfg config.Config) string {
+	if len(cfg.DomainList) == 0 {
+		return p.T("bot.empty")
+	}
+
+	groups := cfg.DomainGroups
+	if len(groups) == 0 {
+		groups = []config.DomainGroup{{Domains: cfg.DomainList}}
+	}
+
+	lines := []string{p.T("bot.monitored", len(cfg.DomainList))}
+	for _, group := range groups {
+		lines = append(lines, "")
+		if group.Title != "" {
+			lines = append(lines, "<b>"+html.EscapeString(group.Title)+":</b>")
+		}
+		for _, domain := range group.Domains {
+			lines = append(lines, html.EscapeString(domain))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func checkDomains(ctx context.Context, p i18n.Printer, args []string) []string {
+	cfg := config.GetConfig()
+
+	if len(args) == 0 {
+		domains, _ := api.CheckDomains(ctx, cfg, cfg.DomainList)
+		rep := report.New(domains, cfg.DomainGroups, cfg.SortOrder)
+		rep.Printer = p
+		return channels.FormatTelegramMessages(p.T("check.header"), rep)
+	}
+
+	var names []string
+	for _, arg := range args {
+		name := normalizeDomainName(arg)
+		if !domainNamePattern.MatchString(name) {
+			return []string{p.T("bot.invalid", html.EscapeString(arg))}
+		}
+		names = append(names, name)
+	}
+
+	domains, _ := api.CheckDomains(ctx, cfg, names)
+	return channels.FormatTelegramMessages(p.T("check.header"), report.Report{Groups: []report.Group{{Domains: domains}}, Printer: p})
+}
+
+func status(ctx context.Context, p i18n.Printer) []string {
+	cfg := config.GetConfig()
+
+	domains, _ := api.CheckDomains(ctx, cfg, cfg.DomainList)
+
+	var problems []api.Domain
+	for _, domain := range domains {
+		if !domain.IsOk() {
+			problems = append(problems, domain)
+		}
+	}
+
+	if len(problems) == 0 {
+		return []string{p.T("bot.all_ok", len(cfg.DomainList))}
+	}
+
+	rep := report.New(problems, cfg.DomainGroups, cfg.SortOrder)
+	rep.Printer = p
+	return channels.FormatTelegramMessages(p.T("bot.problems", len(problems), len(cfg.DomainList)), rep)
+}
+
+func changeDomains(p i18n.Printer, args []string, change func(name string) error, done string) string {
+	if len(args) == 0 {
+		return p.T("bot.specify")
+	}
+
+	var lines []string
+	for _, arg := range args {
+		name := normalizeDomainName(arg)
+		if !domainNamePattern.MatchString(name) {
+			lines = append(lines, "❗️ "+p.T("bot.invalid", html.EscapeString(arg)))
+			continue
+		}
+
+		if err := change(name); err != nil {
+			lines = append(lines, "❗️ "+html.EscapeString(name)+": "+describeError(p, err))
+			continue
+		}
+
+		lines = append(lines, "✅ "+html.EscapeString(name)+" "+done)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func describeError(p i18n.Printer, err error) string {
+	switch {
+	case errors.Is(err, config.ErrNoDomainConfigFile):
+		return p.T("bot.err.no_config")
+	case errors.Is(err, config.ErrDomainExists):
+		return p.T("bot.err.exists")
+	case errors.Is(err, config.ErrDomainNotFound):
+		return p.T("bot.err.not_found")
+	default:
+		return html.EscapeString(err.Error())
+	}
+}
+
+func normalizeDomainName(name string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(name)), ".")
+}
@@ -2,42 +2,134 @@ package config
 
 import (
 	"encoding/json"
+	"github.com/Kravets1996/kz-domain-monitor/internal/i18n"
+	"github.com/Kravets1996/kz-domain-monitor/internal/templates"
+	"github.com/Kravets1996/kz-domain-monitor/pkg/kzdomain"
 	"log"
 	"os"
+	"path"
 	"strconv"
 	"strings"
+	"text/template"
 	"time"
 )
 
 var Configuration Config
 
+// languageChannels lists the channels whose language can be overridden with <CHANNEL>_LANGUAGE.
+var languageChannels = []string{
+	"telegram", "slack", "email", "webhook", "discord", "teams", "mattermost", "rocketchat", "matrix",
+	"ntfy", "gotify", "pushover", "bitrix24", "vkteams", "opsgenie", "issues",
+}
+
+// templateChannels lists the channels that can render reports with <CHANNEL>_TEMPLATE and whether
+// their templates produce HTML.
+var templateChannels = map[string]bool{
+	"telegram": true, "vkteams": true, "email": true,
+	"bitrix24": false, "webhook": false, "ntfy": false, "gotify": false, "pushover": false,
+}
+
 type Config struct {
-	PSApiToken     string
-	DomainProvider string
-	DomainList     []string
-	DomainGroups   []DomainGroup
-	DaysToExpire   int64
-	SendSuccess    bool
-	SendOnlyErrors bool
-	RequestDelay   time.Duration
-	SortOrder      string
-	Telegram       TelegramConfig
-	Slack          SlackConfig
-	Email          EmailConfig
-	Webhook        WebhookConfig
+	PSApiToken string
+	// PSBatchSize is the number of domains looked up in one ps.kz request.
+	PSBatchSize int
+	// PSCheckBalance compares the ps.kz account balance with the auto-renewals due.
+	PSCheckBalance   bool
+	DomainProvider   string
+	DomainList       []string
+	DomainGroups     []DomainGroup
+	DomainConfigFile string
+	DaysToExpire     int64
+	SendSuccess      bool
+	SendOnlyErrors   bool
+	// StatusSeverities overrides the severity of domain statuses from STATUS_SEVERITY.
+	StatusSeverities map[kzdomain.Status]kzdomain.Severity
+	RequestDelay     time.Duration
+	// RunTimeout limits the duration of a check run. Zero means no limit.
+	RunTimeout time.Duration
+	SortOrder  string
+	// Language is the default language of messages: ru, kk or en.
+	Language string
+	// Timezone is used to count calendar days until expiration and to show expiration dates.
+	Timezone *time.Location
+	// ChannelLanguages overrides Language for a channel, e.g. "email" -> "en".
+	ChannelLanguages map[string]string
+	// Templates holds the user templates of channels from <CHANNEL>_TEMPLATE.
+	Templates map[string]*templates.Template
+	// Import adds the domains of the ps.kz account to the checked domains.
+	Import     ImportConfig
+	Telegram   TelegramConfig
+	Slack      SlackConfig
+	Email      EmailConfig
+	Webhook    WebhookConfig
+	Discord    DiscordConfig
+	Teams      TeamsConfig
+	Mattermost MattermostConfig
+	RocketChat RocketChatConfig
+	Matrix     MatrixConfig
+	Ntfy       NtfyConfig
+	Gotify     GotifyConfig
+	Pushover   PushoverConfig
+	Bitrix24   Bitrix24Config
+	Syslog     SyslogConfig
+	Journald   JournaldConfig
+	MQTT       MQTTConfig
+	NATS       NATSConfig
+	AMQP       AMQPConfig
+	VKTeams    VKTeamsConfig
+	PagerDuty  PagerDutyConfig
+	Opsgenie   OpsgenieConfig
+	Issues     IssuesConfig
+	// StateFile keeps open alerts between runs for integrations that resolve them.
+	StateFile string
+	// ErrorLogFile receives failed provider responses. Empty disables it.
+	ErrorLogFile string
+}
+
+// ImportConfig selects the domains of the ps.kz account that are checked in addition to the configured ones.
+type ImportConfig struct {
+	Enabled bool
+	// Include and Exclude are glob patterns of domain names, e.g. "*.kz". Empty Include matches every domain.
+	Include []string
+	Exclude []string
+}
+
+// Matches reports whether the domain matches any of the Include patterns and none of the Exclude patterns.
+func (c ImportConfig) Matches(name string) bool {
+	return (len(c.Include) == 0 || matchAny(c.Include, name)) && !matchAny(c.Exclude, name)
+}
+
+func matchAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(strings.ToLower(pattern), strings.ToLower(name)); matched {
+			return true
+		}
+	}
+	return false
 }
 
 // DomainGroup represents a named group of domains from the JSON config.
 type DomainGroup struct {
-	Title   string
-	Domains []string
+	Title        string
+	Domains      []string
+	SlackChannel string
+	// Owners maps domains to the user responsible for them in the issue tracker.
+	Owners map[string]string
+	// DaysToExpire overrides DAYS_TO_EXPIRE for the group domains. Zero keeps DAYS_TO_EXPIRE.
+	DaysToExpire int64
 }
 
 // jsonDomainEntry represents either a domain entry or a group in the JSON config.
 type jsonDomainEntry struct {
-	Domain string            `json:"domain"`
-	Title  string            `json:"title"`
-	Items  []jsonDomainEntry `json:"items"`
+	Domain string            `json:"domain,omitempty"`
+	Title  string            `json:"title,omitempty"`
+	Items  []jsonDomainEntry `json:"items,omitempty"`
+	// SlackChannel routes the group to a Slack channel ID when SLACK_BOT_TOKEN is used.
+	SlackChannel string `json:"slackChannel,omitempty"`
+	// Owner is assigned to renewal issues. Set on a group, it applies to all of its domains.
+	Owner string `json:"owner,omitempty"`
+	// DaysToExpire overrides DAYS_TO_EXPIRE for the domains of a group.
+	DaysToExpire int64 `json:"daysToExpire,omitempty"`
 }
 
 // loadDomainsFromJSON reads a JSON config file and extracts domain list and group structure.
@@ -70,35 +162,65 @@ func extractDomains(entries []jsonDomainEntry) []string {
 // Grouped entries (with items) become named groups; top-level domain entries are collected into an unnamed group.
 func extractGroups(entries []jsonDomainEntry) []DomainGroup {
 	var groups []DomainGroup
-	var ungrouped []string
+	var ungrouped []jsonDomainEntry
 
 	for _, e := range entries {
 		if len(e.Items) > 0 {
 			domains := extractDomains(e.Items)
 			if len(domains) > 0 {
-				groups = append(groups, DomainGroup{Title: e.Title, Domains: domains})
+				groups = append(groups, DomainGroup{
+					Title:        e.Title,
+					Domains:      domains,
+					SlackChannel: e.SlackChannel,
+					Owners:       extractOwners(e.Items, e.Owner),
+					DaysToExpire: e.DaysToExpire,
+				})
 			}
 		} else if e.Domain != "" {
-			ungrouped = append(ungrouped, strings.TrimSpace(e.Domain))
+			ungrouped = append(ungrouped, e)
 		}
 	}
 
 	if len(ungrouped) > 0 {
-		groups = append(groups, DomainGroup{Title: "", Domains: ungrouped})
+		groups = append(groups, DomainGroup{Title: "", Domains: extractDomains(ungrouped), Owners: extractOwners(ungrouped, "")})
 	}
 
 	return groups
 }
 
+// extractOwners maps domains to their owners. Nested entries inherit the owner of their group.
+func extractOwners(entries []jsonDomainEntry, owner string) map[string]string {
+	owners := make(map[string]string)
+	for _, e := range entries {
+		entryOwner := owner
+		if e.Owner != "" {
+			entryOwner = e.Owner
+		}
+		if e.Domain != "" && entryOwner != "" {
+			owners[strings.TrimSpace(e.Domain)] = entryOwner
+		}
+		for domain, itemOwner := range extractOwners(e.Items, entryOwner) {
+			owners[domain] = itemOwner
+		}
+	}
+	return owners
+}
+
 type TelegramConfig struct {
 	Enabled  bool
 	BotToken string
 	ChatID   string
+	// AllowedChatIDs lists chats allowed to send commands to the bot. Defaults to ChatID.
+	AllowedChatIDs []string
 }
 
 type SlackConfig struct {
 	Enabled    bool
 	WebhookURL string
+	// BotToken switches delivery from the incoming webhook to chat.postMessage.
+	BotToken string
+	Channel  string
+	Thread   bool
 }
 
 type EmailConfig struct {
@@ -109,6 +231,15 @@ type EmailConfig struct {
 	Password string
 	From     string
 	To       []string
+	// Subject is a text/template executed with report.Stats. Empty uses the default subject of the email language.
+	Subject   string
+	AttachCSV bool
+	// Security is one of "tls" (implicit TLS), "starttls" or "none".
+	Security string
+	// Auth is one of "plain", "login", "cram-md5" or "none".
+	Auth               string
+	CAFile             string
+	InsecureSkipVerify bool
 }
 
 type WebhookConfig struct {
@@ -116,7 +247,149 @@ type WebhookConfig struct {
 	URL     string
 }
 
-func loadDomainConfig() ([]string, []DomainGroup) {
+type DiscordConfig struct {
+	Enabled    bool
+	WebhookURL string
+}
+
+type TeamsConfig struct {
+	Enabled    bool
+	WebhookURL string
+}
+
+type MattermostConfig struct {
+	Enabled    bool
+	WebhookURL string
+	Channel    string
+	Username   string
+	IconURL    string
+}
+
+type MatrixConfig struct {
+	Enabled       bool
+	HomeserverURL string
+	AccessToken   string
+	RoomID        string
+}
+
+type NtfyConfig struct {
+	Enabled  bool
+	TopicURL string
+	Token    string
+}
+
+type GotifyConfig struct {
+	Enabled  bool
+	URL      string
+	AppToken string
+	Priority int
+}
+
+type PushoverConfig struct {
+	Enabled  bool
+	AppToken string
+	UserKey  string
+}
+
+type Bitrix24Config struct {
+	Enabled    bool
+	WebhookURL string
+	// DialogID is a chat ("chat42") or a user ID.
+	DialogID string
+	// Tasks creates a renewal task per expiring domain, assigned to ResponsibleID.
+	Tasks         bool
+	ResponsibleID string
+}
+
+type VKTeamsConfig struct {
+	Enabled  bool
+	APIURL   string
+	BotToken string
+	ChatID   string
+}
+
+type SyslogConfig struct {
+	Enabled bool
+	// Network is "udp", "tcp" or "tls"; empty sends to the local syslog daemon.
+	Network  string
+	Address  string
+	Facility int
+	AppName  string
+	CAFile   string
+}
+
+type JournaldConfig struct {
+	Enabled bool
+}
+
+type MQTTConfig struct {
+	Enabled     bool
+	BrokerURL   string
+	ClientID    string
+	Username    string
+	Password    string
+	TopicPrefix string
+	QoS         byte
+	Retain      bool
+}
+
+type NATSConfig struct {
+	Enabled       bool
+	URL           string
+	CredsFile     string
+	SubjectPrefix string
+}
+
+type AMQPConfig struct {
+	Enabled  bool
+	URL      string
+	Exchange string
+}
+
+// syslogFacilities maps facility names to their codes (RFC 5424, section 6.2.1).
+var syslogFacilities = map[string]int{
+	"kern": 0, "user": 1, "mail": 2, "daemon": 3, "auth": 4, "syslog": 5, "lpr": 6, "news": 7,
+	"uucp": 8, "cron": 9, "authpriv": 10, "ftp": 11,
+	"local0": 16, "local1": 17, "local2": 18, "local3": 19, "local4": 20, "local5": 21, "local6": 22, "local7": 23,
+}
+
+type PagerDutyConfig struct {
+	Enabled    bool
+	RoutingKey string
+	EventsURL  string
+}
+
+type OpsgenieConfig struct {
+	Enabled bool
+	APIKey  string
+	APIURL  string
+}
+
+// IssuesConfig opens renewal issues in GitHub, GitLab or Jira.
+type IssuesConfig struct {
+	Enabled bool
+	// Tracker is one of "github", "gitlab" or "jira".
+	Tracker string
+	URL     string
+	Token   string
+	// Project is "owner/repo" for GitHub, a project path or ID for GitLab and a project key for Jira.
+	Project string
+	// Username enables basic auth in Jira Cloud (account email with an API token).
+	Username  string
+	IssueType string
+	Labels    []string
+}
+
+type RocketChatConfig struct {
+	Enabled    bool
+	WebhookURL string
+	Channel    string
+	Alias      string
+	AvatarURL  string
+}
+
+// loadDomainConfig loads the configured domains. The list may be empty only when the domains are imported.
+func loadDomainConfig(imported bool) ([]string, []DomainGroup) {
 	jsonFile := os.Getenv(`DOMAIN_CONFIG_FILE`)
 	envList := os.Getenv(`DOMAIN_LIST`)
 
@@ -132,6 +405,9 @@ func loadDomainConfig() ([]string, []DomainGroup) {
 	}
 
 	if envList == "" {
+		if imported {
+			return nil, nil
+		}
 		panic("Environment variable DOMAIN_LIST is not set")
 	}
 	return strings.Split(envList, ","), nil
@@ -140,49 +416,248 @@ func loadDomainConfig() ([]string, []DomainGroup) {
 func Init() {
 	daysToExpireInt, _ := strconv.ParseInt(getEnv(`DAYS_TO_EXPIRE`, "5"), 10, 64)
 	requestDelayInt, _ := strconv.ParseInt(getEnv(`REQUEST_DELAY`, "3"), 10, 64)
+	psBatchSize, _ := strconv.Atoi(getEnv(`PS_BATCH_SIZE`, "20"))
+	gotifyPriority, _ := strconv.ParseInt(getEnv(`GOTIFY_PRIORITY`, "5"), 10, 64)
+
+	mqttQoS, _ := strconv.ParseUint(getEnv(`MQTT_QOS`, "1"), 10, 8)
+
 	domainProvider := getEnv(`DOMAIN_PROVIDER`, "rdap")
 
+	importDomains := getEnv(`PS_IMPORT_DOMAINS`, "false") == "true"
+	psCheckBalance := getEnv(`PS_CHECK_BALANCE`, "false") == "true"
+
 	psApiToken := ""
-	if domainProvider == "pskz" {
+	if domainProvider == "pskz" || importDomains || psCheckBalance {
 		psApiToken = getEnvStrict(`PS_GRAPHQL_TOKEN`)
 	} else {
 		psApiToken = os.Getenv(`PS_GRAPHQL_TOKEN`)
 	}
 
-	domainList, domainGroups := loadDomainConfig()
+	domainList, domainGroups := loadDomainConfig(importDomains)
+
+	allowedChatIDs := splitAndTrim(os.Getenv(`TELEGRAM_ALLOWED_CHAT_IDS`))
+	if len(allowedChatIDs) == 0 {
+		allowedChatIDs = splitAndTrim(os.Getenv(`TELEGRAM_CHAT_ID`))
+	}
+
+	// Port 465 is used for implicit TLS, other ports (587, 25) usually expect STARTTLS.
+	emailPort := getEnv(`EMAIL_PORT`, "465")
+	defaultEmailSecurity := "starttls"
+	if emailPort == "465" {
+		defaultEmailSecurity = "tls"
+	}
 
 	Configuration = Config{
-		PSApiToken:     psApiToken,
-		DomainProvider: domainProvider,
-		DomainList:     domainList,
-		DomainGroups:   domainGroups,
-		DaysToExpire:   daysToExpireInt,
-		SendSuccess:    getEnv(`SEND_ON_SUCCESS`, "true") == "true",
-		SendOnlyErrors: getEnv(`SEND_ONLY_ERRORS`, "false") == "true",
-		SortOrder:      getEnv(`SORT_ORDER`, "default"),
-		RequestDelay:   time.Second * time.Duration(requestDelayInt),
+		PSApiToken:       psApiToken,
+		PSBatchSize:      psBatchSize,
+		PSCheckBalance:   psCheckBalance,
+		DomainProvider:   domainProvider,
+		DomainList:       domainList,
+		DomainGroups:     domainGroups,
+		DomainConfigFile: os.Getenv(`DOMAIN_CONFIG_FILE`),
+		DaysToExpire:     daysToExpireInt,
+		SendSuccess:      getEnv(`SEND_ON_SUCCESS`, "true") == "true",
+		SendOnlyErrors:   getEnv(`SEND_ONLY_ERRORS`, "false") == "true",
+		SortOrder:        getEnv(`SORT_ORDER`, "default"),
+		Language:         strings.ToLower(getEnv(`LANGUAGE`, i18n.DefaultLanguage)),
+		ChannelLanguages: make(map[string]string),
+		Templates:        make(map[string]*templates.Template),
+		RequestDelay:     time.Second * time.Duration(requestDelayInt),
 		Telegram: TelegramConfig{
-			Enabled:  getEnv(`TELEGRAM_ENABLED`, "true") == "true",
-			BotToken: os.Getenv(`TELEGRAM_BOT_TOKEN`),
-			ChatID:   os.Getenv(`TELEGRAM_CHAT_ID`),
+			Enabled:        getEnv(`TELEGRAM_ENABLED`, "true") == "true",
+			BotToken:       os.Getenv(`TELEGRAM_BOT_TOKEN`),
+			ChatID:         os.Getenv(`TELEGRAM_CHAT_ID`),
+			AllowedChatIDs: allowedChatIDs,
 		},
 		Slack: SlackConfig{
 			Enabled:    getEnv(`SLACK_ENABLED`, "false") == "true",
 			WebhookURL: os.Getenv(`SLACK_WEBHOOK_URL`),
+			BotToken:   os.Getenv(`SLACK_BOT_TOKEN`),
+			Channel:    os.Getenv(`SLACK_CHANNEL`),
+			Thread:     getEnv(`SLACK_THREAD`, "false") == "true",
 		},
 		Email: EmailConfig{
-			Enabled:  getEnv(`EMAIL_ENABLED`, "false") == "true",
-			Host:     os.Getenv(`EMAIL_HOST`),
-			Port:     getEnv(`EMAIL_PORT`, "465"),
-			Username: os.Getenv(`EMAIL_USERNAME`),
-			Password: os.Getenv(`EMAIL_PASSWORD`),
-			From:     os.Getenv(`EMAIL_FROM`),
-			To:       splitAndTrim(os.Getenv(`EMAIL_TO`)),
+			Enabled:            getEnv(`EMAIL_ENABLED`, "false") == "true",
+			Host:               os.Getenv(`EMAIL_HOST`),
+			Port:               emailPort,
+			Username:           os.Getenv(`EMAIL_USERNAME`),
+			Password:           os.Getenv(`EMAIL_PASSWORD`),
+			From:               os.Getenv(`EMAIL_FROM`),
+			To:                 splitAndTrim(os.Getenv(`EMAIL_TO`)),
+			Subject:            os.Getenv(`EMAIL_SUBJECT`),
+			AttachCSV:          getEnv(`EMAIL_ATTACH_CSV`, "false") == "true",
+			Security:           strings.ToLower(getEnv(`EMAIL_SECURITY`, defaultEmailSecurity)),
+			Auth:               strings.ToLower(getEnv(`EMAIL_AUTH`, "plain")),
+			CAFile:             os.Getenv(`EMAIL_CA_FILE`),
+			InsecureSkipVerify: getEnv(`EMAIL_INSECURE_SKIP_VERIFY`, "false") == "true",
 		},
 		Webhook: WebhookConfig{
 			Enabled: getEnv(`WEBHOOK_ENABLED`, "false") == "true",
 			URL:     os.Getenv(`WEBHOOK_URL`),
 		},
+		Discord: DiscordConfig{
+			Enabled:    getEnv(`DISCORD_ENABLED`, "false") == "true",
+			WebhookURL: os.Getenv(`DISCORD_WEBHOOK_URL`),
+		},
+		Teams: TeamsConfig{
+			Enabled:    getEnv(`TEAMS_ENABLED`, "false") == "true",
+			WebhookURL: os.Getenv(`TEAMS_WEBHOOK_URL`),
+		},
+		Mattermost: MattermostConfig{
+			Enabled:    getEnv(`MATTERMOST_ENABLED`, "false") == "true",
+			WebhookURL: os.Getenv(`MATTERMOST_WEBHOOK_URL`),
+			Channel:    os.Getenv(`MATTERMOST_CHANNEL`),
+			Username:   os.Getenv(`MATTERMOST_USERNAME`),
+			IconURL:    os.Getenv(`MATTERMOST_ICON_URL`),
+		},
+		RocketChat: RocketChatConfig{
+			Enabled:    getEnv(`ROCKETCHAT_ENABLED`, "false") == "true",
+			WebhookURL: os.Getenv(`ROCKETCHAT_WEBHOOK_URL`),
+			Channel:    os.Getenv(`ROCKETCHAT_CHANNEL`),
+			Alias:      os.Getenv(`ROCKETCHAT_ALIAS`),
+			AvatarURL:  os.Getenv(`ROCKETCHAT_AVATAR_URL`),
+		},
+		Matrix: MatrixConfig{
+			Enabled:       getEnv(`MATRIX_ENABLED`, "false") == "true",
+			HomeserverURL: os.Getenv(`MATRIX_HOMESERVER_URL`),
+			AccessToken:   os.Getenv(`MATRIX_ACCESS_TOKEN`),
+			RoomID:        os.Getenv(`MATRIX_ROOM_ID`),
+		},
+		Ntfy: NtfyConfig{
+			Enabled:  getEnv(`NTFY_ENABLED`, "false") == "true",
+			TopicURL: os.Getenv(`NTFY_TOPIC_URL`),
+			Token:    os.Getenv(`NTFY_TOKEN`),
+		},
+		Gotify: GotifyConfig{
+			Enabled:  getEnv(`GOTIFY_ENABLED`, "false") == "true",
+			URL:      os.Getenv(`GOTIFY_URL`),
+			AppToken: os.Getenv(`GOTIFY_APP_TOKEN`),
+			Priority: int(gotifyPriority),
+		},
+		Pushover: PushoverConfig{
+			Enabled:  getEnv(`PUSHOVER_ENABLED`, "false") == "true",
+			AppToken: os.Getenv(`PUSHOVER_APP_TOKEN`),
+			UserKey:  os.Getenv(`PUSHOVER_USER_KEY`),
+		},
+		Bitrix24: Bitrix24Config{
+			Enabled:       getEnv(`BITRIX24_ENABLED`, "false") == "true",
+			WebhookURL:    os.Getenv(`BITRIX24_WEBHOOK_URL`),
+			DialogID:      os.Getenv(`BITRIX24_DIALOG_ID`),
+			Tasks:         getEnv(`BITRIX24_TASKS`, "false") == "true",
+			ResponsibleID: os.Getenv(`BITRIX24_RESPONSIBLE_ID`),
+		},
+		VKTeams: VKTeamsConfig{
+			Enabled:  getEnv(`VKTEAMS_ENABLED`, "false") == "true",
+			APIURL:   getEnv(`VKTEAMS_API_URL`, "https://myteam.mail.ru/bot/v1"),
+			BotToken: os.Getenv(`VKTEAMS_BOT_TOKEN`),
+			ChatID:   os.Getenv(`VKTEAMS_CHAT_ID`),
+		},
+		Syslog: SyslogConfig{
+			Enabled: getEnv(`SYSLOG_ENABLED`, "false") == "true",
+			Network: strings.ToLower(os.Getenv(`SYSLOG_NETWORK`)),
+			Address: os.Getenv(`SYSLOG_ADDRESS`),
+			AppName: getEnv(`SYSLOG_APP_NAME`, "kz-domain-monitor"),
+			CAFile:  os.Getenv(`SYSLOG_CA_FILE`),
+		},
+		Journald: JournaldConfig{
+			Enabled: getEnv(`JOURNALD_ENABLED`, "false") == "true",
+		},
+		MQTT: MQTTConfig{
+			Enabled:     getEnv(`MQTT_ENABLED`, "false") == "true",
+			BrokerURL:   os.Getenv(`MQTT_BROKER_URL`),
+			ClientID:    getEnv(`MQTT_CLIENT_ID`, "kz-domain-monitor"),
+			Username:    os.Getenv(`MQTT_USERNAME`),
+			Password:    os.Getenv(`MQTT_PASSWORD`),
+			TopicPrefix: getEnv(`MQTT_TOPIC_PREFIX`, "kzdm"),
+			QoS:         byte(mqttQoS),
+			Retain:      getEnv(`MQTT_RETAIN`, "true") == "true",
+		},
+		NATS: NATSConfig{
+			Enabled:       getEnv(`NATS_ENABLED`, "false") == "true",
+			URL:           getEnv(`NATS_URL`, "nats://localhost:4222"),
+			CredsFile:     os.Getenv(`NATS_CREDS_FILE`),
+			SubjectPrefix: getEnv(`NATS_SUBJECT_PREFIX`, "kzdm"),
+		},
+		AMQP: AMQPConfig{
+			Enabled:  getEnv(`AMQP_ENABLED`, "false") == "true",
+			URL:      os.Getenv(`AMQP_URL`),
+			Exchange: getEnv(`AMQP_EXCHANGE`, "kzdm"),
+		},
+		PagerDuty: PagerDutyConfig{
+			Enabled:    getEnv(`PAGERDUTY_ENABLED`, "false") == "true",
+			RoutingKey: os.Getenv(`PAGERDUTY_ROUTING_KEY`),
+			EventsURL:  getEnv(`PAGERDUTY_EVENTS_URL`, "https://events.pagerduty.com/v2/enqueue"),
+		},
+		Opsgenie: OpsgenieConfig{
+			Enabled: getEnv(`OPSGENIE_ENABLED`, "false") == "true",
+			APIKey:  os.Getenv(`OPSGENIE_API_KEY`),
+			APIURL:  getEnv(`OPSGENIE_API_URL`, "https://api.opsgenie.com"),
+		},
+		Issues: IssuesConfig{
+			Enabled:   getEnv(`ISSUES_ENABLED`, "false") == "true",
+			Tracker:   strings.ToLower(getEnv(`ISSUES_TRACKER`, "github")),
+			URL:       os.Getenv(`ISSUES_URL`),
+			Token:     os.Getenv(`ISSUES_TOKEN`),
+			Project:   os.Getenv(`ISSUES_PROJECT`),
+			Username:  os.Getenv(`ISSUES_USERNAME`),
+			IssueType: getEnv(`ISSUES_JIRA_ISSUE_TYPE`, "Task"),
+			Labels:    splitAndTrim(getEnv(`ISSUES_LABELS`, "kz-domain-monitor")),
+		},
+		Import: ImportConfig{
+			Enabled: importDomains,
+			Include: splitAndTrim(os.Getenv(`PS_IMPORT_INCLUDE`)),
+			Exclude: splitAndTrim(os.Getenv(`PS_IMPORT_EXCLUDE`)),
+		},
+		StateFile:    getEnv(`STATE_FILE`, "state.json"),
+		ErrorLogFile: getEnv(`ERROR_LOG_FILE`, "error.log"),
+	}
+
+	if !i18n.Supported(Configuration.Language) {
+		panic("Unknown LANGUAGE: " + Configuration.Language)
+	}
+	i18n.SetDefault(Configuration.Language)
+
+	Configuration.StatusSeverities = parseStatusSeverities(os.Getenv(`STATUS_SEVERITY`))
+
+	if runTimeout := os.Getenv(`RUN_TIMEOUT`); runTimeout != "" {
+		timeout, err := time.ParseDuration(runTimeout)
+		if err != nil || timeout < 0 {
+			panic("Invalid RUN_TIMEOUT: " + runTimeout)
+		}
+		Configuration.RunTimeout = timeout
+	}
+
+	if timezone := os.Getenv(`TIMEZONE`); timezone != "" {
+		location, err := time.LoadLocation(timezone)
+		if err != nil {
+			panic("Unknown TIMEZONE: " + timezone)
+		}
+		Configuration.Timezone = location
+	}
+
+	for _, channel := range languageChannels {
+		key := strings.ToUpper(channel) + "_LANGUAGE"
+		lang := strings.ToLower(os.Getenv(key))
+		if lang == "" {
+			continue
+		}
+		if !i18n.Supported(lang) {
+			panic("Unknown " + key + ": " + lang)
+		}
+		Configuration.ChannelLanguages[channel] = lang
+	}
+
+	for channel, html := range templateChannels {
+		key := strings.ToUpper(channel) + "_TEMPLATE"
+		path := os.Getenv(key)
+		if path == "" {
+			continue
+		}
+		tmpl, err := templates.Load(path, html, i18n.New(Configuration.LanguageFor(channel)))
+		if err != nil {
+			panic(key + " is invalid: " + err.Error())
+		}
+		Configuration.Templates[channel] = tmpl
 	}
 
 	if Configuration.Telegram.Enabled {
@@ -192,15 +667,40 @@ func Init() {
 	}
 
 	if Configuration.Slack.Enabled {
-		if Configuration.Slack.WebhookURL == "" {
+		if Configuration.Slack.BotToken != "" {
+			if Configuration.Slack.Channel == "" {
+				panic("Slack channel is not set")
+			}
+		} else if Configuration.Slack.WebhookURL == "" {
 			panic("Slack webhook URL is not set")
 		}
 	}
 
 	if Configuration.Email.Enabled {
-		if Configuration.Email.Host == "" || Configuration.Email.Username == "" || Configuration.Email.From == "" || len(Configuration.Email.To) == 0 {
+		if Configuration.Email.Host == "" || Configuration.Email.From == "" || len(Configuration.Email.To) == 0 {
 			panic("Email config is not set")
 		}
+
+		switch Configuration.Email.Security {
+		case "tls", "starttls", "none":
+		default:
+			panic("Unknown EMAIL_SECURITY: " + Configuration.Email.Security)
+		}
+
+		switch Configuration.Email.Auth {
+		case "plain", "login", "cram-md5":
+			if Configuration.Email.Username == "" {
+				panic("Email username is not set")
+			}
+		case "none":
+		default:
+			panic("Unknown EMAIL_AUTH: " + Configuration.Email.Auth)
+		}
+
+		printer := i18n.New(Configuration.LanguageFor("email"))
+		if _, err := template.New("subject").Funcs(printer.TemplateFuncs()).Parse(Configuration.Email.Subject); err != nil {
+			panic("Email subject template is invalid: " + err.Error())
+		}
 	}
 
 	if Configuration.Webhook.Enabled {
@@ -208,12 +708,193 @@ func Init() {
 			panic("Webhook URL is not set")
 		}
 	}
+
+	if Configuration.Discord.Enabled {
+		if Configuration.Discord.WebhookURL == "" {
+			panic("Discord webhook URL is not set")
+		}
+	}
+
+	if Configuration.Teams.Enabled {
+		if Configuration.Teams.WebhookURL == "" {
+			panic("Teams webhook URL is not set")
+		}
+	}
+
+	if Configuration.Mattermost.Enabled {
+		if Configuration.Mattermost.WebhookURL == "" {
+			panic("Mattermost webhook URL is not set")
+		}
+	}
+
+	if Configuration.RocketChat.Enabled {
+		if Configuration.RocketChat.WebhookURL == "" {
+			panic("Rocket.Chat webhook URL is not set")
+		}
+	}
+
+	if Configuration.Matrix.Enabled {
+		if Configuration.Matrix.HomeserverURL == "" || Configuration.Matrix.AccessToken == "" || Configuration.Matrix.RoomID == "" {
+			panic("Matrix config is not set")
+		}
+	}
+
+	if Configuration.Ntfy.Enabled {
+		if Configuration.Ntfy.TopicURL == "" {
+			panic("ntfy topic URL is not set")
+		}
+	}
+
+	if Configuration.Gotify.Enabled {
+		if Configuration.Gotify.URL == "" || Configuration.Gotify.AppToken == "" {
+			panic("Gotify config is not set")
+		}
+	}
+
+	if Configuration.Pushover.Enabled {
+		if Configuration.Pushover.AppToken == "" || Configuration.Pushover.UserKey == "" {
+			panic("Pushover config is not set")
+		}
+	}
+
+	if Configuration.Bitrix24.Enabled {
+		if Configuration.Bitrix24.WebhookURL == "" || Configuration.Bitrix24.DialogID == "" {
+			panic("Bitrix24 config is not set")
+		}
+		if Configuration.Bitrix24.Tasks && Configuration.Bitrix24.ResponsibleID == "" {
+			panic("Bitrix24 responsible ID is not set")
+		}
+	}
+
+	if Configuration.VKTeams.Enabled {
+		if Configuration.VKTeams.BotToken == "" || Configuration.VKTeams.ChatID == "" {
+			panic("VK Teams config is not set")
+		}
+	}
+
+	if Configuration.Syslog.Enabled {
+		facility, ok := syslogFacilities[strings.ToLower(getEnv(`SYSLOG_FACILITY`, "daemon"))]
+		if !ok {
+			panic("Unknown SYSLOG_FACILITY: " + os.Getenv(`SYSLOG_FACILITY`))
+		}
+		Configuration.Syslog.Facility = facility
+
+		switch Configuration.Syslog.Network {
+		case "":
+		case "udp", "tcp", "tls":
+			if Configuration.Syslog.Address == "" {
+				panic("Syslog address is not set")
+			}
+		default:
+			panic("Unknown SYSLOG_NETWORK: " + Configuration.Syslog.Network)
+		}
+	}
+
+	if Configuration.MQTT.Enabled {
+		if Configuration.MQTT.BrokerURL == "" {
+			panic("MQTT broker URL is not set")
+		}
+		if Configuration.MQTT.QoS > 2 {
+			panic("MQTT_QOS must be 0, 1 or 2")
+		}
+	}
+
+	if Configuration.AMQP.Enabled {
+		if Configuration.AMQP.URL == "" {
+			panic("AMQP URL is not set")
+		}
+	}
+
+	if Configuration.PagerDuty.Enabled {
+		if Configuration.PagerDuty.RoutingKey == "" {
+			panic("PagerDuty routing key is not set")
+		}
+	}
+
+	if Configuration.Opsgenie.Enabled {
+		if Configuration.Opsgenie.APIKey == "" {
+			panic("Opsgenie API key is not set")
+		}
+	}
+
+	if Configuration.Issues.Enabled {
+		if Configuration.Issues.Token == "" || Configuration.Issues.Project == "" {
+			panic("Issue tracker config is not set")
+		}
+
+		switch Configuration.Issues.Tracker {
+		case "github":
+			if Configuration.Issues.URL == "" {
+				Configuration.Issues.URL = "https://api.github.com"
+			}
+		case "gitlab":
+			if Configuration.Issues.URL == "" {
+				Configuration.Issues.URL = "https://gitlab.com"
+			}
+		case "jira":
+			if Configuration.Issues.URL == "" {
+				panic("Jira URL is not set")
+			}
+		default:
+			panic("Unknown ISSUES_TRACKER: " + Configuration.Issues.Tracker)
+		}
+	}
 }
 
 func GetConfig() Config {
 	return Configuration
 }
 
+// GroupTitle returns the title of the group the domain belongs to, or an empty string.
+func (c Config) GroupTitle(domain string) string {
+	for _, group := range c.DomainGroups {
+		for _, name := range group.Domains {
+			if name == domain {
+				return group.Title
+			}
+		}
+	}
+	return ""
+}
+
+// DaysToExpireFor returns the "daysToExpire" of the domain group or DAYS_TO_EXPIRE.
+func (c Config) DaysToExpireFor(domain string) int64 {
+	for _, group := range c.DomainGroups {
+		for _, name := range group.Domains {
+			if name == domain && group.DaysToExpire > 0 {
+				return group.DaysToExpire
+			}
+		}
+	}
+	return c.DaysToExpire
+}
+
+// Location returns the TIMEZONE location, the local time zone by default.
+func (c Config) Location() *time.Location {
+	if c.Timezone != nil {
+		return c.Timezone
+	}
+	return time.Local
+}
+
+// LanguageFor returns the language of a channel: its <CHANNEL>_LANGUAGE override or LANGUAGE.
+func (c Config) LanguageFor(channel string) string {
+	if lang, ok := c.ChannelLanguages[channel]; ok {
+		return lang
+	}
+	return c.Language
+}
+
+// Owner returns the owner of the domain from the JSON config, or an empty string.
+func (c Config) Owner(domain string) string {
+	for _, group := range c.DomainGroups {
+		if owner, ok := group.Owners[domain]; ok {
+			return owner
+		}
+	}
+	return ""
+}
+
 func getEnv(key, fallback string) string {
 	if value, ok := os.LookupEnv(key); ok {
 		return value
@@ -221,6 +902,23 @@ func getEnv(key, fallback string) string {
 	return fallback
 }
 
+// parseStatusSeverities parses "status=severity" pairs separated by commas, e.g. "available=warning,no_date=ok".
+func parseStatusSeverities(value string) map[kzdomain.Status]kzdomain.Severity {
+	severities := make(map[kzdomain.Status]kzdomain.Severity)
+
+	for _, pair := range splitAndTrim(strings.ToLower(value)) {
+		name, severityName, _ := strings.Cut(pair, "=")
+		status := kzdomain.Status(strings.TrimSpace(name))
+		severity, ok := kzdomain.ParseSeverity(strings.TrimSpace(severityName))
+		if !ok || !status.IsValid() {
+			panic("Invalid STATUS_SEVERITY: " + pair)
+		}
+		severities[status] = severity
+	}
+
+	return severities
+}
+
 func getEnvStrict(key string) string {
 	if value, ok := os.LookupEnv(key); ok {
 		return value
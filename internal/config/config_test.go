@@ -0,0 +1,105 @@
+package config
+
+import (
+	"github.com/Kravets1996/kz-domain-monitor/pkg/kzdomain"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestOwner_InheritedFromGroup(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "domains.json")
+	data := `[
+		{"title": "Бухгалтерия", "owner": "accountant", "items": [
+			{"domain": "example.kz"},
+			{"domain": "shop.kz", "owner": "manager"}
+		]},
+		{"domain": "egov.kz", "owner": "admin"},
+		{"domain": "free.kz"}
+	]`
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, groups, err := loadDomainsFromJSON(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := Config{DomainGroups: groups}
+
+	tests := map[string]string{
+		"example.kz": "accountant",
+		"shop.kz":    "manager",
+		"egov.kz":    "admin",
+		"free.kz":    "",
+	}
+	for domain, want := range tests {
+		if got := cfg.Owner(domain); got != want {
+			t.Errorf("Owner(%s) = %q, want %q", domain, got, want)
+		}
+	}
+
+	if got := cfg.GroupTitle("shop.kz"); got != "Бухгалтерия" {
+		t.Errorf("GroupTitle(shop.kz) = %q", got)
+	}
+}
+
+func TestDaysToExpireFor_Group(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "domains.json")
+	data := `[
+		{"title": "Сайты", "daysToExpire": 60, "items": [{"domain": "example.kz"}]},
+		{"domain": "egov.kz"}
+	]`
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, groups, err := loadDomainsFromJSON(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := Config{DomainGroups: groups, DaysToExpire: 5}
+
+	if got := cfg.DaysToExpireFor("example.kz"); got != 60 {
+		t.Errorf("DaysToExpireFor(example.kz) = %d, want 60", got)
+	}
+	if got := cfg.DaysToExpireFor("egov.kz"); got != 5 {
+		t.Errorf("DaysToExpireFor(egov.kz) = %d, want 5", got)
+	}
+}
+
+func TestImportConfig_Matches(t *testing.T) {
+	cfg := ImportConfig{Include: []string{"*.kz", "*.қаз"}, Exclude: []string{"test-*"}}
+
+	tests := map[string]bool{
+		"example.kz":      true,
+		"Shop.KZ":         true,
+		"пример.қаз":      true,
+		"example.com":     false,
+		"test-example.kz": false,
+	}
+	for domain, want := range tests {
+		if got := cfg.Matches(domain); got != want {
+			t.Errorf("Matches(%s) = %v, want %v", domain, got, want)
+		}
+	}
+
+	if !(ImportConfig{}).Matches("example.com") {
+		t.Error("empty Include should match every domain")
+	}
+}
+
+func TestParseStatusSeverities(t *testing.T) {
+	severities := parseStatusSeverities("available=warning, NO_DATE=ok")
+
+	if len(severities) != 2 || severities[kzdomain.StatusAvailable] != kzdomain.SeverityWarning || severities[kzdomain.StatusNoDate] != kzdomain.SeverityOk {
+		t.Errorf("unexpected severities: %v", severities)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("unknown status should panic")
+		}
+	}()
+	parseStatusSeverities("renewed=ok")
+}
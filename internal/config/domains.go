@@ -0,0 +1,98 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+)
+
+var (
+	ErrNoDomainConfigFile = errors.New("DOMAIN_CONFIG_FILE is not set")
+	ErrDomainExists       = errors.New("domain is already in the list")
+	ErrDomainNotFound     = errors.New("domain is not in the list")
+)
+
+// AddDomain appends a domain to the top level of DOMAIN_CONFIG_FILE and reloads the domain list.
+func AddDomain(name string) error {
+	return updateDomainConfig(func(entries []jsonDomainEntry) ([]jsonDomainEntry, error) {
+		for _, domain := range extractDomains(entries) {
+			if domain == name {
+				return nil, ErrDomainExists
+			}
+		}
+		return append(entries, jsonDomainEntry{Domain: name}), nil
+	})
+}
+
+// RemoveDomain removes a domain from DOMAIN_CONFIG_FILE, including nested groups, and reloads the domain list.
+func RemoveDomain(name string) error {
+	return updateDomainConfig(func(entries []jsonDomainEntry) ([]jsonDomainEntry, error) {
+		result, removed := removeDomain(entries, name)
+		if !removed {
+			return nil, ErrDomainNotFound
+		}
+		return result, nil
+	})
+}
+
+// removeDomain drops entries for the domain and groups left without items.
+func removeDomain(entries []jsonDomainEntry, name string) ([]jsonDomainEntry, bool) {
+	var (
+		result  []jsonDomainEntry
+		removed bool
+	)
+
+	for _, e := range entries {
+		if e.Domain == name {
+			removed = true
+			continue
+		}
+		if len(e.Items) > 0 {
+			var itemRemoved bool
+			e.Items, itemRemoved = removeDomain(e.Items, name)
+			removed = removed || itemRemoved
+			if len(e.Items) == 0 && e.Domain == "" {
+				continue
+			}
+		}
+		result = append(result, e)
+	}
+
+	return result, removed
+}
+
+func updateDomainConfig(update func([]jsonDomainEntry) ([]jsonDomainEntry, error)) error {
+	path := Configuration.DomainConfigFile
+	if path == "" {
+		return ErrNoDomainConfigFile
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var entries []jsonDomainEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+
+	entries, err = update(entries)
+	if err != nil {
+		return err
+	}
+
+	data, err = json.MarshalIndent(entries, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return err
+	}
+
+	Configuration.DomainList = extractDomains(entries)
+	Configuration.DomainGroups = extractGroups(entries)
+
+	return nil
+}
@@ -0,0 +1,39 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRemoveDomain_DropsEmptyGroups(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "domains.json")
+	data := `[
+		{"title": "Группа", "items": [{"domain": "example.kz"}]},
+		{"domain": "egov.kz", "title": "Отдельный домен"}
+	]`
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	Configuration = Config{DomainConfigFile: path}
+
+	if err := RemoveDomain("example.kz"); err != nil {
+		t.Fatal(err)
+	}
+
+	domains, groups, err := loadDomainsFromJSON(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(domains) != 1 || domains[0] != "egov.kz" {
+		t.Errorf("unexpected domains: %v", domains)
+	}
+	if len(groups) != 1 || groups[0].Title != "" {
+		t.Errorf("empty group should be removed: %v", groups)
+	}
+
+	if err := RemoveDomain("example.kz"); !errors.Is(err, ErrDomainNotFound) {
+		t.Errorf("expected ErrDomainNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,72 @@
+package i18n
+
+var en = map[string]string{
+	"header":         "Time left until domain expiration: ",
+	"check.header":   "Check results:",
+	"import.group":   "Not in the configuration (ps.kz account)",
+	"import.failed":  "Failed to load the ps.kz account domains: %s",
+	"balance.low":    "The ps.kz balance (%s ₸) does not cover the auto-renewal of domains for %s ₸",
+	"balance.failed": "Failed to check the ps.kz balance: %s",
+
+	"days.one":   "%d day",
+	"days.other": "%d days",
+	"days.today": "expires today",
+
+	"expired.one":   "expired %d day ago",
+	"expired.other": "expired %d days ago",
+
+	"domains.one":   "%d domain",
+	"domains.other": "%d domains",
+
+	"of_domains.one":   "%d domain",
+	"of_domains.other": "%d domains",
+
+	"domain.available": "❌ Domain is available for registration: %s",
+	"domain.no_date":   "❗️ Expiration date of %s is unavailable",
+	"status.available": "❌ Available for registration",
+	"status.no_date":   "❗️ Expiration date is unavailable",
+
+	"severity.ok":       "✅ All domains are ok",
+	"severity.warning":  "⚠️ Registration expires soon",
+	"severity.error":    "❗️ Check failed",
+	"severity.critical": "❗️ Registration expired or domain is available",
+
+	"summary": "Domains: %d, need attention: %d",
+
+	"email.subject":    `kz-domain-monitor: {{if .Problems}}{{.Problems}} of {{n "of_domains" .Total}} need attention (expiring: {{.Expiring}}, expired: {{.Expired}}, errors: {{.Errors}}){{else}}all domains are ok ({{.Total}}){{end}}`,
+	"email.domain":     "Domain",
+	"email.status":     "Status",
+	"email.expiration": "Expiration date",
+
+	"issue.title":       "Renew domain %s",
+	"issue.domain":      "Domain: %s",
+	"issue.available":   "Status: available for registration",
+	"issue.expiration":  "Expiration date: %s",
+	"issue.days_left":   "Time left: %s",
+	"issue.status":      "Status: %s",
+	"issue.registrar":   "Registrar: %s",
+	"issue.renewed":     "Domain renewed until %s",
+	"issue.unmonitored": "Domain is no longer monitored",
+	"alert.closed":      "Domain renewed or no longer monitored",
+
+	"bot.help": `Available commands:
+/check - check all domains from the list
+/check example.kz - check the given domains
+/status - show only domains with problems
+/list - show the monitored domains
+/add example.kz - add domains to the list
+/remove example.kz - remove domains from the list`,
+	"bot.unknown":       "Unknown command.",
+	"bot.empty":         "The domain list is empty.",
+	"bot.monitored":     "Monitored domains: %d",
+	"bot.invalid":       "Invalid domain name: %s",
+	"bot.all_ok":        "✅ All domains are ok (%d)",
+	"bot.problems":      "Domains with problems: %d of %d",
+	"bot.busy":          "A check is already running, wait for its result.",
+	"bot.specify":       "Specify a domain name, e.g. example.kz",
+	"bot.added":         "added to the list",
+	"bot.removed":       "removed from the list",
+	"bot.err.no_config": "the list can only be changed when DOMAIN_CONFIG_FILE is used",
+	"bot.err.exists":    "domain is already in the list",
+	"bot.err.not_found": "domain is not in the list",
+}
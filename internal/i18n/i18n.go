@@ -0,0 +1,113 @@
+// Package i18n provides the message catalog and CLDR plural rules for Russian, Kazakh and English.
+package i18n
+
+import (
+	"fmt"
+	"text/template"
+)
+
+// DefaultLanguage is used for unknown languages and missing messages.
+const DefaultLanguage = "ru"
+
+var catalogs = map[string]map[string]string{
+	"ru": ru,
+	"kk": kk,
+	"en": en,
+}
+
+// defaultLanguage is the LANGUAGE setting, used by the zero Printer.
+var defaultLanguage = DefaultLanguage
+
+// SetDefault sets the language of the zero Printer.
+func SetDefault(lang string) {
+	if Supported(lang) {
+		defaultLanguage = lang
+	}
+}
+
+// Supported reports whether lang has a catalog.
+func Supported(lang string) bool {
+	_, ok := catalogs[lang]
+	return ok
+}
+
+// Printer formats catalog messages in a language. The zero value uses the default language.
+type Printer struct {
+	lang string
+}
+
+// New returns a printer for lang. An empty lang follows the default language.
+func New(lang string) Printer {
+	return Printer{lang: lang}
+}
+
+// Lang returns the effective language of the printer.
+func (p Printer) Lang() string {
+	if Supported(p.lang) {
+		return p.lang
+	}
+	return defaultLanguage
+}
+
+// T formats the message key with args. Missing messages fall back to Russian, then to the key itself.
+func (p Printer) T(key string, args ...any) string {
+	format := p.lookup(key)
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// N formats the plural message key for n, e.g. N("days", 21) is "21 день" in Russian.
+// Messages are stored as key.one, key.few, key.many and key.other.
+func (p Printer) N(key string, n int64) string {
+	category := PluralCategory(p.Lang(), n)
+	if _, ok := catalogs[p.Lang()][key+"."+category]; !ok {
+		category = "other"
+	}
+	return p.T(key+"."+category, n)
+}
+
+// TemplateFuncs returns functions for user templates: {{n "domains" .Total}} renders a plural message.
+func (p Printer) TemplateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"n": func(key string, n int) string { return p.N(key, int64(n)) },
+		"t": func(key string) string { return p.T(key) },
+	}
+}
+
+func (p Printer) lookup(key string) string {
+	if message, ok := catalogs[p.Lang()][key]; ok {
+		return message
+	}
+	if message, ok := catalogs[DefaultLanguage][key]; ok {
+		return message
+	}
+	return key
+}
+
+// PluralCategory returns the CLDR plural category of an integer: one, few, many or other.
+func PluralCategory(lang string, n int64) string {
+	if n < 0 {
+		n = -n
+	}
+
+	switch lang {
+	case "ru":
+		switch mod10, mod100 := n%10, n%100; {
+		case mod10 == 1 && mod100 != 11:
+			return "one"
+		case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+			return "few"
+		default:
+			return "many"
+		}
+	case "en", "kk":
+		if n == 1 {
+			return "one"
+		}
+		return "other"
+	default:
+		return "other"
+	}
+}
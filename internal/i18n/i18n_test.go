@@ -0,0 +1,81 @@
+package i18n
+
+import (
+	"strings"
+	"testing"
+	"text/template"
+)
+
+func TestPluralCategory_Russian(t *testing.T) {
+	tests := map[int64]string{
+		0: "many", 1: "one", 2: "few", 4: "few", 5: "many", 11: "many", 12: "many", 14: "many",
+		21: "one", 22: "few", 25: "many", 101: "one", 111: "many", 112: "many", 122: "few", -1: "one", -5: "many",
+	}
+
+	for n, want := range tests {
+		if got := PluralCategory("ru", n); got != want {
+			t.Errorf("PluralCategory(ru, %d) = %s, want %s", n, got, want)
+		}
+	}
+}
+
+func TestPrinter_N(t *testing.T) {
+	tests := []struct {
+		lang string
+		n    int64
+		want string
+	}{
+		{"ru", 1, "1 день"},
+		{"ru", 3, "3 дня"},
+		{"ru", 11, "11 дней"},
+		{"ru", 21, "21 день"},
+		{"en", 1, "1 day"},
+		{"en", 0, "0 days"},
+		{"kk", 5, "5 күн"},
+	}
+
+	for _, tt := range tests {
+		if got := New(tt.lang).N("days", tt.n); got != tt.want {
+			t.Errorf("N(%s, %d) = %s, want %s", tt.lang, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestPrinter_DefaultLanguage(t *testing.T) {
+	defer SetDefault(DefaultLanguage)
+
+	SetDefault("en")
+	if got := (Printer{}).T("bot.unknown"); got != "Unknown command." {
+		t.Errorf("zero printer should follow the default language, got %s", got)
+	}
+	if got := New("ru").T("bot.unknown"); got != "Неизвестная команда." {
+		t.Errorf("explicit language should override the default, got %s", got)
+	}
+	if got := New("de").Lang(); got != "en" {
+		t.Errorf("unknown language should fall back to the default, got %s", got)
+	}
+}
+
+// TestCatalogs_Complete checks that every language translates every message and its templates parse.
+func TestCatalogs_Complete(t *testing.T) {
+	for lang, catalog := range catalogs {
+		for key := range ru {
+			if plural := strings.LastIndex(key, "."); plural != -1 && isPluralForm(key[plural+1:]) {
+				if _, ok := catalog[key[:plural]+".other"]; ok {
+					continue
+				}
+			}
+			if _, ok := catalog[key]; !ok {
+				t.Errorf("%s: missing message %s", lang, key)
+			}
+		}
+
+		if _, err := template.New("subject").Funcs(New(lang).TemplateFuncs()).Parse(catalog["email.subject"]); err != nil {
+			t.Errorf("%s: invalid email subject: %v", lang, err)
+		}
+	}
+}
+
+func isPluralForm(category string) bool {
+	return category == "one" || category == "few" || category == "many" || category == "other"
+}
@@ -0,0 +1,73 @@
+package i18n
+
+// Kazakh nouns keep the singular form after numerals, so one and other plural forms are the same.
+var kk = map[string]string{
+	"header":         "Доменнің мерзімі аяқталуына қалды: ",
+	"check.header":   "Тексеру нәтижелері:",
+	"import.group":   "Конфигурацияда жоқ (ps.kz аккаунты)",
+	"import.failed":  "ps.kz аккаунтының домендерін жүктеу мүмкін болмады: %s",
+	"balance.low":    "ps.kz балансы (%s ₸) домендерді автоұзартуға жеткіліксіз: қажет %s ₸",
+	"balance.failed": "ps.kz балансын тексеру мүмкін болмады: %s",
+
+	"days.one":   "%d күн",
+	"days.other": "%d күн",
+	"days.today": "бүгін аяқталады",
+
+	"expired.one":   "%d күн бұрын аяқталды",
+	"expired.other": "%d күн бұрын аяқталды",
+
+	"domains.one":   "%d домен",
+	"domains.other": "%d домен",
+
+	"of_domains.one":   "%d доменнің",
+	"of_domains.other": "%d доменнің",
+
+	"domain.available": "❌ Домен тіркеуге қолжетімді: %s",
+	"domain.no_date":   "❗️ %s доменінің мерзімі аяқталу күні қолжетімсіз",
+	"status.available": "❌ Тіркеуге қолжетімді",
+	"status.no_date":   "❗️ Мерзімі аяқталу күні қолжетімсіз",
+
+	"severity.ok":       "✅ Барлық домендер ретте",
+	"severity.warning":  "⚠️ Тіркеу мерзімі жақында аяқталады",
+	"severity.error":    "❗️ Тексеру қатесі",
+	"severity.critical": "❗️ Тіркеу мерзімі өтті немесе домен бос",
+
+	"summary": "Домендер: %d, назар аударуды қажет етеді: %d",
+
+	"email.subject":    `kz-domain-monitor: {{if .Problems}}{{n "of_domains" .Total}} {{.Problems}} назар аударуды қажет етеді (мерзімі аяқталуда: {{.Expiring}}, мерзімі өтті: {{.Expired}}, қателер: {{.Errors}}){{else}}барлық домендер ретте ({{.Total}}){{end}}`,
+	"email.domain":     "Домен",
+	"email.status":     "Күйі",
+	"email.expiration": "Аяқталу күні",
+
+	"issue.title":       "%s доменін ұзарту",
+	"issue.domain":      "Домен: %s",
+	"issue.available":   "Күйі: тіркеуге қолжетімді",
+	"issue.expiration":  "Аяқталу күні: %s",
+	"issue.days_left":   "Қалды: %s",
+	"issue.status":      "Күйі: %s",
+	"issue.registrar":   "Тіркеуші: %s",
+	"issue.renewed":     "Домен %s дейін ұзартылды",
+	"issue.unmonitored": "Домен енді бақыланбайды",
+	"alert.closed":      "Домен ұзартылды немесе енді бақыланбайды",
+
+	"bot.help": `Қолжетімді командалар:
+/check - тізімдегі барлық домендерді тексеру
+/check example.kz - көрсетілген домендерді тексеру
+/status - тек ақаулары бар домендерді көрсету
+/list - бақыланатын домендер тізімін көрсету
+/add example.kz - домендерді тізімге қосу
+/remove example.kz - домендерді тізімнен жою`,
+	"bot.unknown":       "Белгісіз команда.",
+	"bot.empty":         "Домендер тізімі бос.",
+	"bot.monitored":     "Бақыланатын домендер: %d",
+	"bot.invalid":       "Жарамсыз домен атауы: %s",
+	"bot.all_ok":        "✅ Барлық домендер ретте (%d)",
+	"bot.problems":      "Ақаулары бар домендер: %d / %d",
+	"bot.busy":          "Тексеру орындалып жатыр, нәтижесін күтіңіз.",
+	"bot.specify":       "Домен атауын көрсетіңіз, мысалы: example.kz",
+	"bot.added":         "тізімге қосылды",
+	"bot.removed":       "тізімнен жойылды",
+	"bot.err.no_config": "тізімді өзгерту тек DOMAIN_CONFIG_FILE пайдаланғанда қолжетімді",
+	"bot.err.exists":    "домен тізімде бар",
+	"bot.err.not_found": "домен тізімде жоқ",
+}
@@ -0,0 +1,77 @@
+package i18n
+
+var ru = map[string]string{
+	"header":         "До истечения домена осталось: ",
+	"check.header":   "Результаты проверки:",
+	"import.group":   "Нет в конфигурации (аккаунт ps.kz)",
+	"import.failed":  "Не удалось загрузить домены аккаунта ps.kz: %s",
+	"balance.low":    "Баланс ps.kz (%s ₸) не покрывает автопродление доменов на %s ₸",
+	"balance.failed": "Не удалось проверить баланс ps.kz: %s",
+
+	"days.one":   "%d день",
+	"days.few":   "%d дня",
+	"days.many":  "%d дней",
+	"days.today": "истекает сегодня",
+
+	"expired.one":  "истёк %d день назад",
+	"expired.few":  "истёк %d дня назад",
+	"expired.many": "истёк %d дней назад",
+
+	"domains.one":  "%d домен",
+	"domains.few":  "%d домена",
+	"domains.many": "%d доменов",
+
+	// of_domains is the genitive used after "из": "из 1 домена", "из 5 доменов".
+	"of_domains.one":  "%d домена",
+	"of_domains.few":  "%d доменов",
+	"of_domains.many": "%d доменов",
+
+	"domain.available": "❌ Домен доступен для регистрации: %s",
+	"domain.no_date":   "❗️ Дата истечения оплаты домена %s недоступна",
+	"status.available": "❌ Доступен для регистрации",
+	"status.no_date":   "❗️ Дата истечения недоступна",
+
+	"severity.ok":       "✅ Все домены в порядке",
+	"severity.warning":  "⚠️ Скоро истекает срок регистрации",
+	"severity.error":    "❗️ Ошибка проверки",
+	"severity.critical": "❗️ Срок регистрации истёк или домен свободен",
+
+	"summary": "Доменов: %d, требуют внимания: %d",
+
+	"email.subject":    `kz-domain-monitor: {{if .Problems}}требуют внимания {{.Problems}} из {{n "of_domains" .Total}} (истекает: {{.Expiring}}, истекло: {{.Expired}}, ошибок: {{.Errors}}){{else}}все домены в порядке ({{.Total}}){{end}}`,
+	"email.domain":     "Домен",
+	"email.status":     "Статус",
+	"email.expiration": "Дата истечения",
+
+	"issue.title":       "Продлить домен %s",
+	"issue.domain":      "Домен: %s",
+	"issue.available":   "Статус: доступен для регистрации",
+	"issue.expiration":  "Дата истечения: %s",
+	"issue.days_left":   "Осталось: %s",
+	"issue.status":      "Статус: %s",
+	"issue.registrar":   "Регистратор: %s",
+	"issue.renewed":     "Домен продлён до %s",
+	"issue.unmonitored": "Домен больше не отслеживается",
+	"alert.closed":      "Домен продлён или больше не отслеживается",
+
+	"bot.help": `Доступные команды:
+/check - проверить все домены из списка
+/check example.kz - проверить указанные домены
+/status - показать только домены с проблемами
+/list - показать список отслеживаемых доменов
+/add example.kz - добавить домены в список
+/remove example.kz - удалить домены из списка`,
+	"bot.unknown":       "Неизвестная команда.",
+	"bot.empty":         "Список доменов пуст.",
+	"bot.monitored":     "Отслеживается доменов: %d",
+	"bot.invalid":       "Некорректное доменное имя: %s",
+	"bot.all_ok":        "✅ Все домены в порядке (%d)",
+	"bot.problems":      "Домены с проблемами: %d из %d",
+	"bot.busy":          "Проверка уже выполняется, дождитесь результата.",
+	"bot.specify":       "Укажите доменное имя, например: example.kz",
+	"bot.added":         "добавлен в список",
+	"bot.removed":       "удалён из списка",
+	"bot.err.no_config": "изменение списка доступно только при использовании DOMAIN_CONFIG_FILE",
+	"bot.err.exists":    "домен уже в списке",
+	"bot.err.not_found": "домена нет в списке",
+}
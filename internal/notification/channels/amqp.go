@@ -0,0 +1,81 @@
+package channels
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+type AMQPChannel struct {
+	url      string
+	exchange string
+}
+
+// NewAMQPChannel creates a channel that publishes events to an existing exchange with the severity
+// (ok, warning, error or critical) as the routing key.
+func NewAMQPChannel(url, exchange string) *AMQPChannel {
+	return &AMQPChannel{url: url, exchange: exchange}
+}
+
+// Publish sends every event as a persistent JSON message and waits for publisher confirms.
+func (a *AMQPChannel) Publish(events []DomainEvent) error {
+	conn, err := amqp.DialConfig(a.url, amqp.Config{Dial: amqp.DefaultDial(10 * time.Second)})
+	if err != nil {
+		return fmt.Errorf("amqp: dial failed: %w", err)
+	}
+	defer conn.Close()
+
+	channel, err := conn.Channel()
+	if err != nil {
+		return fmt.Errorf("amqp: channel failed: %w", err)
+	}
+	defer channel.Close()
+
+	if err := channel.Confirm(false); err != nil {
+		return fmt.Errorf("amqp: confirm mode failed: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	for _, event := range events {
+		publishing, err := amqpPublishing(event)
+		if err != nil {
+			return err
+		}
+
+		confirmation, err := channel.PublishWithDeferredConfirmWithContext(ctx, a.exchange, event.Severity, false, false, publishing)
+		if err != nil {
+			return fmt.Errorf("amqp: publish failed: %w", err)
+		}
+
+		acked, err := confirmation.WaitContext(ctx)
+		if err != nil {
+			return fmt.Errorf("amqp: confirm failed: %w", err)
+		}
+		if !acked {
+			return fmt.Errorf("amqp: message for %s was not acknowledged", event.Domain)
+		}
+	}
+
+	return nil
+}
+
+func amqpPublishing(event DomainEvent) (amqp.Publishing, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return amqp.Publishing{}, fmt.Errorf("amqp: marshal failed: %w", err)
+	}
+
+	return amqp.Publishing{
+		ContentType:  "application/json",
+		DeliveryMode: amqp.Persistent,
+		Timestamp:    event.CheckedAt,
+		AppId:        "kz-domain-monitor",
+		Headers:      amqp.Table{"domain": event.Domain, "group": event.Group},
+		Body:         payload,
+	}, nil
+}
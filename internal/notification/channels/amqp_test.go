@@ -0,0 +1,78 @@
+package channels
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+func TestAMQPPublishing(t *testing.T) {
+	checkedAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	publishing, err := amqpPublishing(DomainEvent{Domain: "example.kz", Group: "Сайты", Severity: "warning", CheckedAt: checkedAt})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if publishing.DeliveryMode != amqp.Persistent || publishing.ContentType != "application/json" || !publishing.Timestamp.Equal(checkedAt) {
+		t.Errorf("unexpected publishing: %+v", publishing)
+	}
+	if publishing.Headers["domain"] != "example.kz" || publishing.Headers["group"] != "Сайты" {
+		t.Errorf("unexpected headers: %v", publishing.Headers)
+	}
+}
+
+// TestAMQPChannel_Publish runs against a local broker, e.g. docker run --rm -p 5672:5672 rabbitmq:4
+func TestAMQPChannel_Publish(t *testing.T) {
+	url := os.Getenv("KZDM_TEST_AMQP_URL")
+	if url == "" {
+		t.Skip("KZDM_TEST_AMQP_URL is not set")
+	}
+
+	conn, err := amqp.Dial(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	channel, err := conn.Channel()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer channel.Close()
+
+	if err := channel.ExchangeDeclare("kzdm-test", "direct", false, true, false, false, nil); err != nil {
+		t.Fatal(err)
+	}
+	queue, err := channel.QueueDeclare("", false, true, true, false, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := channel.QueueBind(queue.Name, "critical", "kzdm-test", false, nil); err != nil {
+		t.Fatal(err)
+	}
+	deliveries, err := channel.Consume(queue.Name, "", true, true, false, false, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	events := []DomainEvent{{Domain: "ok.kz", Severity: "ok"}, {Domain: "expired.kz", Severity: "critical"}}
+	if err := NewAMQPChannel(url, "kzdm-test").Publish(events); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case delivery := <-deliveries:
+		var event DomainEvent
+		if err := json.Unmarshal(delivery.Body, &event); err != nil {
+			t.Fatal(err)
+		}
+		if event.Domain != "expired.kz" {
+			t.Errorf("only critical events should be routed to the queue, got %+v", event)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("event was not received")
+	}
+}
@@ -0,0 +1,103 @@
+package channels
+
+import (
+	"github.com/Kravets1996/kz-domain-monitor/internal/report"
+	"strings"
+	"unicode/utf8"
+)
+
+// chatAttachment is the legacy Slack message attachment understood by Mattermost and Rocket.Chat.
+type chatAttachment struct {
+	Fallback string      `json:"fallback,omitempty"`
+	Color    string      `json:"color"`
+	Title    string      `json:"title,omitempty"`
+	Text     string      `json:"text,omitempty"`
+	Fields   []chatField `json:"fields,omitempty"`
+}
+
+type chatField struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+	Short bool   `json:"short"`
+}
+
+// chatText returns the message text of Mattermost and Rocket.Chat: the formatted header and the notices.
+func chatText(header string, rep report.Report) string {
+	if notices := noticeText(rep); notices != "" {
+		return header + "\n" + notices
+	}
+	return header
+}
+
+// chatAttachments renders every group as an attachment colored by its worst severity, with a field per domain.
+// Groups larger than limit characters are split into several attachments.
+func chatAttachments(rep report.Report, limit int) []chatAttachment {
+	var attachments []chatAttachment
+	for _, group := range rep.Groups {
+		attachment := chatAttachment{
+			Color: severityColor(group.Severity()),
+			Title: group.Title,
+		}
+
+		var fallback []string
+		size := utf8.RuneCountInString(group.Title)
+		add := func() {
+			attachment.Fallback = strings.Join(fallback, "\n")
+			attachments = append(attachments, attachment)
+			attachment.Fields, fallback = nil, nil
+			size = utf8.RuneCountInString(group.Title)
+		}
+
+		for _, domain := range group.Domains {
+			field := chatField{
+				Title: domain.Name,
+				Value: domainStatus(rep.Printer, domain),
+				Short: true,
+			}
+			line := domain.Message(rep.Printer)
+
+			// The field and its fallback line with the line break.
+			fieldSize := utf8.RuneCountInString(field.Title+field.Value+line) + 1
+			if len(attachment.Fields) > 0 && size+fieldSize > limit {
+				add()
+			}
+
+			attachment.Fields = append(attachment.Fields, field)
+			fallback = append(fallback, line)
+			size += fieldSize
+		}
+
+		if len(attachment.Fields) > 0 {
+			add()
+		}
+	}
+	return attachments
+}
+
+// packChatAttachments distributes attachments over posts of at most limit characters. The first post also
+// carries text, which is counted against its limit.
+func packChatAttachments(text string, attachments []chatAttachment, limit int) [][]chatAttachment {
+	posts := [][]chatAttachment{nil}
+	size := utf8.RuneCountInString(text)
+
+	for _, attachment := range attachments {
+		attachmentSize := chatAttachmentSize(attachment)
+		if size > 0 && size+attachmentSize > limit {
+			posts = append(posts, nil)
+			size = 0
+		}
+		posts[len(posts)-1] = append(posts[len(posts)-1], attachment)
+		size += attachmentSize
+	}
+
+	return posts
+}
+
+// chatAttachmentSize returns the number of characters of the attachment text.
+func chatAttachmentSize(attachment chatAttachment) int {
+	size := utf8.RuneCountInString(attachment.Title + attachment.Fallback)
+	for _, field := range attachment.Fields {
+		size += utf8.RuneCountInString(field.Title + field.Value)
+	}
+	return size
+}
@@ -0,0 +1,162 @@
+package channels
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/Kravets1996/kz-domain-monitor/internal/i18n"
+	"github.com/Kravets1996/kz-domain-monitor/internal/report"
+	"strconv"
+	"strings"
+)
+
+const (
+	bitrix24Integration = "bitrix24"
+	// bitrix24MessageLimit is a conservative size of a single chat message.
+	bitrix24MessageLimit = 4000
+)
+
+type Bitrix24Channel struct {
+	webhookURL string
+	dialogID   string
+}
+
+// bitrix24Response is the envelope of REST API responses; errors are also reported with a 4xx status.
+type bitrix24Response struct {
+	Result           json.RawMessage `json:"result"`
+	Error            string          `json:"error"`
+	ErrorDescription string          `json:"error_description"`
+}
+
+// NewBitrix24Channel creates a channel for an incoming webhook, e.g. https://company.bitrix24.kz/rest/1/secret/.
+// dialogID is a chat ("chat42") or a user ID.
+func NewBitrix24Channel(webhookURL, dialogID string) *Bitrix24Channel {
+	return &Bitrix24Channel{webhookURL: bitrix24BaseURL(webhookURL), dialogID: dialogID}
+}
+
+// NewBitrix24TasksChannel creates a channel that tracks domain renewals as Bitrix24 tasks written in lang.
+// The owner passed to Sync must be a Bitrix24 user ID.
+func NewBitrix24TasksChannel(webhookURL, lang string) *IssuesChannel {
+	return &IssuesChannel{
+		tracker:     &bitrix24Tracker{webhookURL: bitrix24BaseURL(webhookURL)},
+		integration: bitrix24Integration,
+		printer:     i18n.New(lang),
+	}
+}
+
+// Send posts the report with im.message.add using BB-code formatting.
+func (b *Bitrix24Channel) Send(header string, rep report.Report) error {
+	blocks, err := reportBlocks(header, rep, bitrix24Blocks)
+	if err != nil {
+		return err
+	}
+
+	for _, message := range splitBlocks(blocks, bitrix24MessageLimit) {
+		_, err := bitrix24Call(b.webhookURL, "im.message.add", map[string]string{
+			"DIALOG_ID":   b.dialogID,
+			"MESSAGE":     message,
+			"URL_PREVIEW": "N",
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func bitrix24Blocks(header string, rep report.Report) []string {
+	blocks := []string{"[B]" + strings.TrimSpace(header) + "[/B]"}
+	if notices := noticeText(rep); notices != "" {
+		blocks = append(blocks, notices)
+	}
+
+	for _, group := range rep.Groups {
+		if len(group.Domains) == 0 {
+			continue
+		}
+
+		var lines []string
+		if group.Title != "" {
+			lines = append(lines, "[B]"+group.Title+":[/B]")
+		}
+		for _, domain := range group.Domains {
+			lines = append(lines, domain.Message(rep.Printer))
+		}
+		blocks = append(blocks, strings.Join(lines, "\n"))
+	}
+
+	return blocks
+}
+
+type bitrix24Tracker struct {
+	webhookURL string
+}
+
+func (b *bitrix24Tracker) createIssue(issue trackerIssue) (string, error) {
+	fields := map[string]any{
+		"TITLE":       issue.Title,
+		"DESCRIPTION": issue.Body,
+	}
+	if issue.Assignee != "" {
+		fields["RESPONSIBLE_ID"] = issue.Assignee
+	}
+
+	result, err := bitrix24Call(b.webhookURL, "tasks.task.add", map[string]any{"fields": fields})
+	if err != nil {
+		return "", err
+	}
+
+	var created struct {
+		Task struct {
+			ID json.Number `json:"id"`
+		} `json:"task"`
+	}
+	if err := json.Unmarshal(result, &created); err != nil {
+		return "", fmt.Errorf("bitrix24: failed to parse task: %w", err)
+	}
+
+	return created.Task.ID.String(), nil
+}
+
+func (b *bitrix24Tracker) commentIssue(id, text string) error {
+	_, err := bitrix24Call(b.webhookURL, "task.commentitem.add", map[string]any{
+		"TASKID": id,
+		"FIELDS": map[string]string{"POST_MESSAGE": text},
+	})
+	return err
+}
+
+func (b *bitrix24Tracker) closeIssue(id, comment string) error {
+	if err := b.commentIssue(id, comment); err != nil {
+		return err
+	}
+
+	taskID, err := strconv.Atoi(id)
+	if err != nil {
+		return fmt.Errorf("bitrix24: invalid task id %q", id)
+	}
+
+	_, err = bitrix24Call(b.webhookURL, "tasks.task.complete", map[string]int{"taskId": taskID})
+	return err
+}
+
+// bitrix24Call invokes a REST method of the webhook and returns its result.
+func bitrix24Call(webhookURL, method string, params any) (json.RawMessage, error) {
+	body, err := postJSON("bitrix24", webhookURL+method+".json", params, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var response bitrix24Response
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("bitrix24: failed to parse response: %w", err)
+	}
+	if response.Error != "" {
+		return nil, fmt.Errorf("bitrix24: %s: %s", response.Error, response.ErrorDescription)
+	}
+
+	return response.Result, nil
+}
+
+func bitrix24BaseURL(webhookURL string) string {
+	return strings.TrimSuffix(webhookURL, "/") + "/"
+}
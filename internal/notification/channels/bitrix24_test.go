@@ -0,0 +1,109 @@
+package channels
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/Kravets1996/kz-domain-monitor/internal/api"
+	"github.com/Kravets1996/kz-domain-monitor/internal/report"
+	"github.com/Kravets1996/kz-domain-monitor/internal/state"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBitrix24Channel_Send(t *testing.T) {
+	var (
+		path    string
+		payload map[string]string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatal(err)
+		}
+		fmt.Fprint(w, `{"result": 123}`)
+	}))
+	defer server.Close()
+
+	expiring := time.Now().AddDate(0, 0, 3)
+	rep := report.Report{Groups: []report.Group{{Title: "Сайты", Domains: []api.Domain{{Name: "example.kz", ExpirationDate: &expiring, Policy: testPolicy}}}}}
+
+	if err := NewBitrix24Channel(server.URL+"/rest/1/secret", "chat42").Send("Header ", rep); err != nil {
+		t.Fatal(err)
+	}
+
+	if path != "/rest/1/secret/im.message.add.json" {
+		t.Errorf("unexpected path: %s", path)
+	}
+	expected := "[B]Header[/B]\n\n[B]Сайты:[/B]\n⚠️ 3 дня - example.kz"
+	if payload["DIALOG_ID"] != "chat42" || payload["MESSAGE"] != expected {
+		t.Errorf("unexpected payload: %v", payload)
+	}
+}
+
+func TestBitrix24Channel_SendError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `{"error": "ACCESS_DENIED", "error_description": "Access denied"}`)
+	}))
+	defer server.Close()
+
+	rep := report.Report{Groups: []report.Group{{Domains: []api.Domain{{Name: "free.kz", IsAvailable: true}}}}}
+
+	err := NewBitrix24Channel(server.URL, "1").Send("Header", rep)
+	if err == nil || !strings.Contains(err.Error(), "ACCESS_DENIED") {
+		t.Errorf("expected api error, got %v", err)
+	}
+}
+
+func TestBitrix24Tasks_Sync(t *testing.T) {
+	var methods []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, strings.TrimPrefix(r.URL.Path, "/"))
+		if r.URL.Path == "/tasks.task.add.json" {
+			var params struct {
+				Fields map[string]string `json:"fields"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+				t.Fatal(err)
+			}
+			if params.Fields["RESPONSIBLE_ID"] != "7" {
+				t.Errorf("unexpected fields: %v", params.Fields)
+			}
+			fmt.Fprint(w, `{"result": {"task": {"id": "15"}}}`)
+			return
+		}
+		fmt.Fprint(w, `{"result": true}`)
+	}))
+	defer server.Close()
+
+	alerts, err := state.Load(filepath.Join(t.TempDir(), "state.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	channel := NewBitrix24TasksChannel(server.URL, "ru")
+	responsible := func(string) string { return "7" }
+
+	expiring := time.Now().AddDate(0, 0, 3)
+	renewed := time.Now().AddDate(0, 0, 365)
+
+	if err := channel.Sync([]api.Domain{{Name: "example.kz", ExpirationDate: &expiring, Policy: testPolicy}}, responsible, alerts); err != nil {
+		t.Fatal(err)
+	}
+	if alert, ok := alerts.Get(bitrix24Integration, "example.kz"); !ok || alert.ID != "15" {
+		t.Fatalf("expected task 15 in state, got %+v", alert)
+	}
+
+	if err := channel.Sync([]api.Domain{{Name: "example.kz", ExpirationDate: &renewed, Policy: testPolicy}}, responsible, alerts); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "tasks.task.add.json,task.commentitem.add.json,tasks.task.complete.json"
+	if strings.Join(methods, ",") != expected {
+		t.Errorf("expected %s, got %v", expected, methods)
+	}
+}
@@ -0,0 +1,135 @@
+package channels
+
+import (
+	"github.com/Kravets1996/kz-domain-monitor/internal/api"
+	"github.com/Kravets1996/kz-domain-monitor/internal/report"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Discord webhook limits, see https://discord.com/developers/docs/resources/message#embed-object-embed-limits
+const (
+	discordContentLimit    = 2000
+	discordEmbedsLimit     = 10
+	discordFieldsLimit     = 25
+	discordTitleLimit      = 256
+	discordFieldNameLimit  = 256
+	discordFieldValueLimit = 1024
+	discordTotalLimit      = 6000
+)
+
+type DiscordChannel struct {
+	webhookURL string
+}
+
+type discordMessage struct {
+	Content string         `json:"content,omitempty"`
+	Embeds  []discordEmbed `json:"embeds,omitempty"`
+}
+
+type discordEmbed struct {
+	Title  string         `json:"title,omitempty"`
+	Color  int            `json:"color"`
+	Fields []discordField `json:"fields"`
+}
+
+type discordField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline"`
+}
+
+func NewDiscordChannel(webhookURL string) *DiscordChannel {
+	return &DiscordChannel{webhookURL: webhookURL}
+}
+
+// Send posts the report as embeds, one per group, split over several messages to respect Discord limits.
+func (d *DiscordChannel) Send(header string, rep report.Report) error {
+	messages := packDiscordMessages(discordEmbeds(rep))
+	if len(messages) == 0 {
+		messages = []discordMessage{{}}
+	}
+	messages[0].Content = truncate(strings.Join(append([]string{strings.TrimSpace(header)}, rep.NoticeLines()...), "\n"), discordContentLimit)
+
+	for _, message := range messages {
+		if err := d.post(message); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *DiscordChannel) post(message discordMessage) error {
+	_, err := postJSON("discord", d.webhookURL, message, nil)
+	return err
+}
+
+// discordEmbeds renders every group as an embed colored by its worst severity.
+// Groups exceeding the field or size limits are split into several embeds.
+func discordEmbeds(rep report.Report) []discordEmbed {
+	var embeds []discordEmbed
+	for _, group := range rep.Groups {
+		embed := discordEmbed{Title: truncate(group.Title, discordTitleLimit), Color: discordColor(group.Severity())}
+
+		for _, domain := range group.Domains {
+			field := discordField{
+				Name:   truncate(domain.Name, discordFieldNameLimit),
+				Value:  truncate(domainStatus(rep.Printer, domain), discordFieldValueLimit),
+				Inline: true,
+			}
+
+			full := len(embed.Fields) == discordFieldsLimit ||
+				discordEmbedSize(embed)+utf8.RuneCountInString(field.Name+field.Value) > discordTotalLimit
+			if full {
+				embeds = append(embeds, embed)
+				embed.Fields = nil
+			}
+
+			embed.Fields = append(embed.Fields, field)
+		}
+
+		if len(embed.Fields) > 0 {
+			embeds = append(embeds, embed)
+		}
+	}
+	return embeds
+}
+
+// packDiscordMessages distributes embeds over messages within the embed count and total size limits.
+func packDiscordMessages(embeds []discordEmbed) []discordMessage {
+	var (
+		messages []discordMessage
+		current  discordMessage
+		size     int
+	)
+
+	for _, embed := range embeds {
+		embedSize := discordEmbedSize(embed)
+		if len(current.Embeds) > 0 && (len(current.Embeds) == discordEmbedsLimit || size+embedSize > discordTotalLimit) {
+			messages = append(messages, current)
+			current = discordMessage{}
+			size = 0
+		}
+		current.Embeds = append(current.Embeds, embed)
+		size += embedSize
+	}
+
+	if len(current.Embeds) > 0 {
+		messages = append(messages, current)
+	}
+	return messages
+}
+
+func discordEmbedSize(embed discordEmbed) int {
+	size := utf8.RuneCountInString(embed.Title)
+	for _, field := range embed.Fields {
+		size += utf8.RuneCountInString(field.Name) + utf8.RuneCountInString(field.Value)
+	}
+	return size
+}
+
+func discordColor(severity api.Severity) int {
+	color, _ := strconv.ParseInt(severityColor(severity)[1:], 16, 32)
+	return int(color)
+}
@@ -0,0 +1,83 @@
+package channels
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/Kravets1996/kz-domain-monitor/internal/api"
+	"github.com/Kravets1996/kz-domain-monitor/internal/report"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestDiscordChannel_Send(t *testing.T) {
+	var messages []discordMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var message discordMessage
+		if err := json.NewDecoder(r.Body).Decode(&message); err != nil {
+			t.Fatal(err)
+		}
+		messages = append(messages, message)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	expiration := time.Now().AddDate(0, 0, 90)
+	expired := time.Now().AddDate(0, 0, -5)
+
+	var domains []api.Domain
+	for i := 0; i < 300; i++ {
+		domains = append(domains, api.Domain{Name: fmt.Sprintf("example-%d.kz", i), ExpirationDate: &expiration, Policy: testPolicy})
+	}
+
+	rep := report.Report{Groups: []report.Group{
+		{Title: "Сайты", Domains: domains},
+		{Title: "Истёкшие", Domains: []api.Domain{{Name: "expired.kz", ExpirationDate: &expired, Policy: testPolicy}}},
+	}}
+
+	if err := NewDiscordChannel(server.URL).Send("Header", rep); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(messages) < 2 {
+		t.Fatalf("expected report to be split, got %d messages", len(messages))
+	}
+	if messages[0].Content != "Header" {
+		t.Errorf("first message should contain the header, got %q", messages[0].Content)
+	}
+
+	fields := 0
+	for _, message := range messages {
+		if len(message.Embeds) > discordEmbedsLimit {
+			t.Errorf("message exceeds embed limit: %d", len(message.Embeds))
+		}
+
+		size := 0
+		for _, embed := range message.Embeds {
+			if len(embed.Fields) > discordFieldsLimit {
+				t.Errorf("embed exceeds field limit: %d", len(embed.Fields))
+			}
+			size += discordEmbedSize(embed)
+			fields += len(embed.Fields)
+		}
+		if size > discordTotalLimit {
+			t.Errorf("message exceeds total size limit: %d", size)
+		}
+	}
+	if fields != len(domains)+1 {
+		t.Errorf("expected %d fields, got %d", len(domains)+1, fields)
+	}
+
+	last := messages[len(messages)-1].Embeds
+	if embed := last[len(last)-1]; embed.Title != "Истёкшие" || embed.Color != discordColor(api.SeverityCritical) {
+		t.Errorf("unexpected embed: %+v", embed)
+	}
+	if value := messages[0].Embeds[0].Fields[0].Value; value != "✅ 90 дней" {
+		t.Errorf("unexpected field value: %q", value)
+	}
+	if utf8.RuneCountInString(messages[0].Content) > discordContentLimit {
+		t.Error("content exceeds limit")
+	}
+}
@@ -2,67 +2,86 @@ package channels
 
 import (
 	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"github.com/Kravets1996/kz-domain-monitor/internal/report"
 	"net"
 	"net/smtp"
-	"strings"
+	"os"
 	"time"
 )
 
 type EmailChannel struct {
-	host     string
-	port     string
-	username string
-	password string
-	from     string
-	to       []string
+	host               string
+	port               string
+	username           string
+	password           string
+	from               string
+	to                 []string
+	subject            string
+	attachCSV          bool
+	security           string
+	auth               string
+	caFile             string
+	insecureSkipVerify bool
 }
 
-func NewEmailChannel(host, port, username, password, from string, to []string) *EmailChannel {
-	return &EmailChannel{
-		host:     host,
-		port:     port,
-		username: username,
-		password: password,
-		from:     from,
-		to:       to,
-	}
+// EmailOptions are the SMTP server and message settings of EmailChannel.
+type EmailOptions struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+	To       []string
+	// Subject is a text/template executed with report.Stats. Empty uses the default subject of the email language.
+	Subject   string
+	AttachCSV bool
+	// Security is one of "tls" (implicit TLS), "starttls" or "none".
+	Security string
+	// Auth is one of "plain", "login", "cram-md5" or "none".
+	Auth               string
+	CAFile             string
+	InsecureSkipVerify bool
 }
 
-func (e *EmailChannel) Send(message string) error {
-	addr := e.host + ":" + e.port
-
-	subject := "kz-domain-monitor уведомление"
-	body := "To: " + strings.Join(e.to, ",") + "\r\n" +
-		"From: " + e.from + "\r\n" +
-		"Subject: " + subject + "\r\n" +
-		"Content-Type: text/plain; charset=UTF-8\r\n" +
-		"\r\n" +
-		message
-
-	auth := smtp.PlainAuth("", e.username, e.password, e.host)
-
-	tlsConfig := &tls.Config{
-		ServerName: e.host,
+func NewEmailChannel(options EmailOptions) *EmailChannel {
+	return &EmailChannel{
+		host:               options.Host,
+		port:               options.Port,
+		username:           options.Username,
+		password:           options.Password,
+		from:               options.From,
+		to:                 options.To,
+		subject:            options.Subject,
+		attachCSV:          options.AttachCSV,
+		security:           options.Security,
+		auth:               options.Auth,
+		caFile:             options.CAFile,
+		insecureSkipVerify: options.InsecureSkipVerify,
 	}
+}
 
-	dialer := &net.Dialer{Timeout: 10 * time.Second}
-	conn, err := tls.DialWithDialer(dialer, "tcp", addr, tlsConfig)
-
+// Send delivers the report as an HTML email with a plain-text alternative.
+func (e *EmailChannel) Send(header string, rep report.Report) error {
+	body, err := e.buildEmailMessage(header, rep)
 	if err != nil {
-		// Fallback: try plain SMTP with STARTTLS
-		return e.sendSTARTTLS(addr, auth, body)
+		return err
 	}
-	defer conn.Close()
 
-	client, err := smtp.NewClient(conn, e.host)
+	client, err := e.dial()
 	if err != nil {
-		return fmt.Errorf("email: failed to create smtp client: %w", err)
+		return err
 	}
 	defer client.Close()
 
-	if err = client.Auth(auth); err != nil {
-		return fmt.Errorf("email: auth failed: %w", err)
+	if auth := e.smtpAuth(); auth != nil {
+		if ok, _ := client.Extension("AUTH"); !ok {
+			return fmt.Errorf("email: server does not support AUTH, set EMAIL_AUTH=none for relays without authentication")
+		}
+		if err = client.Auth(auth); err != nil {
+			return fmt.Errorf("email: auth failed: %w", err)
+		}
 	}
 
 	if err = client.Mail(e.from); err != nil {
@@ -80,7 +99,7 @@ func (e *EmailChannel) Send(message string) error {
 		return fmt.Errorf("email: DATA failed: %w", err)
 	}
 
-	_, err = w.Write([]byte(body))
+	_, err = w.Write(body)
 	if err != nil {
 		return fmt.Errorf("email: write failed: %w", err)
 	}
@@ -92,50 +111,81 @@ func (e *EmailChannel) Send(message string) error {
 	return client.Quit()
 }
 
-func (e *EmailChannel) sendSTARTTLS(addr string, auth smtp.Auth, body string) error {
-	tlsConfig := &tls.Config{
-		ServerName: e.host,
+// dial connects to the SMTP server using the configured security mode.
+// STARTTLS is mandatory when selected: the connection is never downgraded to plain text.
+func (e *EmailChannel) dial() (*smtp.Client, error) {
+	addr := net.JoinHostPort(e.host, e.port)
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+
+	tlsConfig, err := e.tlsConfig()
+	if err != nil {
+		return nil, err
 	}
 
-	client, err := smtp.Dial(addr)
+	var conn net.Conn
+	if e.security == "tls" {
+		conn, err = tls.DialWithDialer(dialer, "tcp", addr, tlsConfig)
+		if err != nil {
+			return nil, fmt.Errorf("email: TLS dial failed (use EMAIL_SECURITY=starttls for ports 587/25): %w", err)
+		}
+	} else {
+		conn, err = dialer.Dial("tcp", addr)
+		if err != nil {
+			return nil, fmt.Errorf("email: dial failed: %w", err)
+		}
+	}
+
+	client, err := smtp.NewClient(conn, e.host)
 	if err != nil {
-		return fmt.Errorf("email: dial failed: %w", err)
+		conn.Close()
+		return nil, fmt.Errorf("email: failed to create smtp client: %w", err)
 	}
-	defer client.Close()
 
-	if ok, _ := client.Extension("STARTTLS"); ok {
+	if e.security == "starttls" {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			client.Close()
+			return nil, fmt.Errorf("email: server does not support STARTTLS")
+		}
 		if err = client.StartTLS(tlsConfig); err != nil {
-			return fmt.Errorf("email: STARTTLS failed: %w", err)
+			client.Close()
+			return nil, fmt.Errorf("email: STARTTLS failed: %w", err)
 		}
 	}
 
-	if err = client.Auth(auth); err != nil {
-		return fmt.Errorf("email: auth failed: %w", err)
-	}
+	return client, nil
+}
 
-	if err = client.Mail(e.from); err != nil {
-		return fmt.Errorf("email: MAIL FROM failed: %w", err)
+func (e *EmailChannel) tlsConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		ServerName:         e.host,
+		InsecureSkipVerify: e.insecureSkipVerify,
 	}
 
-	for _, recipient := range e.to {
-		if err = client.Rcpt(recipient); err != nil {
-			return fmt.Errorf("email: RCPT TO failed for %s: %w", recipient, err)
+	if e.caFile != "" {
+		pem, err := os.ReadFile(e.caFile)
+		if err != nil {
+			return nil, fmt.Errorf("email: failed to read CA file: %w", err)
 		}
-	}
 
-	w, err := client.Data()
-	if err != nil {
-		return fmt.Errorf("email: DATA failed: %w", err)
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("email: no certificates found in CA file %s", e.caFile)
+		}
+		tlsConfig.RootCAs = pool
 	}
 
-	_, err = w.Write([]byte(body))
-	if err != nil {
-		return fmt.Errorf("email: write failed: %w", err)
-	}
+	return tlsConfig, nil
+}
 
-	if err = w.Close(); err != nil {
-		return fmt.Errorf("email: close writer failed: %w", err)
+func (e *EmailChannel) smtpAuth() smtp.Auth {
+	switch e.auth {
+	case "none":
+		return nil
+	case "login":
+		return &loginAuth{username: e.username, password: e.password, host: e.host}
+	case "cram-md5":
+		return smtp.CRAMMD5Auth(e.username, e.password)
+	default:
+		return smtp.PlainAuth("", e.username, e.password, e.host)
 	}
-
-	return client.Quit()
 }
@@ -0,0 +1,125 @@
+package channels
+
+import (
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+	"testing"
+)
+
+// fakeSMTPServer accepts a single session without TLS and AUTH and returns the received DATA.
+func fakeSMTPServer(t *testing.T) (string, <-chan string) {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	received := make(chan string, 1)
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		text := textproto.NewConn(conn)
+		text.PrintfLine("220 localhost ESMTP")
+
+		for {
+			line, err := text.ReadLine()
+			if err != nil {
+				return
+			}
+
+			switch command := strings.ToUpper(strings.Fields(line)[0]); command {
+			case "EHLO":
+				text.PrintfLine("250-localhost")
+				text.PrintfLine("250 8BITMIME")
+			case "DATA":
+				text.PrintfLine("354 go ahead")
+				data, _ := text.ReadDotBytes()
+				received <- string(data)
+				text.PrintfLine("250 ok")
+			case "QUIT":
+				text.PrintfLine("221 bye")
+				return
+			default:
+				text.PrintfLine("250 ok")
+			}
+		}
+	}()
+
+	return listener.Addr().String(), received
+}
+
+func newTestEmailChannel(addr, security, auth string) *EmailChannel {
+	host, port, _ := net.SplitHostPort(addr)
+	return &EmailChannel{
+		host:     host,
+		port:     port,
+		username: "user",
+		password: "password",
+		from:     "monitor@example.kz",
+		to:       []string{"admin@example.kz"},
+		subject:  "test",
+		security: security,
+		auth:     auth,
+	}
+}
+
+func TestEmailChannel_Send_NoSecurityNoAuth(t *testing.T) {
+	addr, received := fakeSMTPServer(t)
+
+	if err := newTestEmailChannel(addr, "none", "none").Send("Header", getEmailReport()); err != nil {
+		t.Fatal(err)
+	}
+
+	if data := <-received; !strings.Contains(data, "example.kz") {
+		t.Errorf("unexpected message: %s", data)
+	}
+}
+
+func TestEmailChannel_Send_StartTLSRequired(t *testing.T) {
+	addr, _ := fakeSMTPServer(t)
+
+	err := newTestEmailChannel(addr, "starttls", "none").Send("Header", getEmailReport())
+
+	if err == nil || !strings.Contains(err.Error(), "STARTTLS") {
+		t.Errorf("expected STARTTLS error instead of a plain text fallback, got %v", err)
+	}
+}
+
+func TestEmailChannel_Send_AuthNotSupported(t *testing.T) {
+	addr, _ := fakeSMTPServer(t)
+
+	err := newTestEmailChannel(addr, "none", "login").Send("Header", getEmailReport())
+
+	if err == nil || !strings.Contains(err.Error(), "AUTH") {
+		t.Errorf("expected AUTH error, got %v", err)
+	}
+}
+
+func TestLoginAuth(t *testing.T) {
+	auth := &loginAuth{username: "user", password: "secret", host: "smtp.example.kz"}
+
+	if _, _, err := auth.Start(&smtp.ServerInfo{Name: "smtp.example.kz"}); err == nil {
+		t.Error("credentials should not be sent over an unencrypted connection")
+	}
+
+	mechanism, _, err := auth.Start(&smtp.ServerInfo{Name: "smtp.example.kz", TLS: true})
+	if err != nil || mechanism != "LOGIN" {
+		t.Fatalf("unexpected start: %s, %v", mechanism, err)
+	}
+
+	for challenge, expected := range map[string]string{"Username:": "user", "Password:": "secret"} {
+		response, err := auth.Next([]byte(challenge), true)
+		if err != nil || string(response) != expected {
+			t.Errorf("unexpected response to %s: %s, %v", challenge, response, err)
+		}
+	}
+}
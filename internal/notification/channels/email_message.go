@@ -0,0 +1,307 @@
+package channels
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/csv"
+	"fmt"
+	"github.com/Kravets1996/kz-domain-monitor/internal/api"
+	"github.com/Kravets1996/kz-domain-monitor/internal/report"
+	"html/template"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/textproto"
+	"strconv"
+	"strings"
+	textTemplate "text/template"
+	"time"
+)
+
+var emailHTMLTemplate = template.Must(template.New("email").Parse(`<!DOCTYPE html>
+<html>
+<body style="font-family: Arial, sans-serif; font-size: 14px;">
+<p>{{.Header}}</p>
+{{- range .Notices}}
+<p style="color: #d40e0d;">{{.}}</p>
+{{- end}}
+<table cellpadding="6" cellspacing="0" style="border-collapse: collapse; border: 1px solid #dddddd;">
+<tr style="background-color: #f2f2f2;"><th align="left">{{.Columns.Domain}}</th><th align="left">{{.Columns.Status}}</th><th align="left">{{.Columns.ExpirationDate}}</th></tr>
+{{- range .Groups}}
+{{- if .Title}}
+<tr><td colspan="3" style="border-top: 1px solid #dddddd;"><b>{{.Title}}</b></td></tr>
+{{- end}}
+{{- range .Rows}}
+<tr style="background-color: {{.Color}};"><td style="border-top: 1px solid #dddddd;">{{.Name}}</td><td style="border-top: 1px solid #dddddd;">{{.Status}}</td><td style="border-top: 1px solid #dddddd;">{{.ExpirationDate}}</td></tr>
+{{- end}}
+{{- end}}
+</table>
+</body>
+</html>
+`))
+
+type emailHTMLData struct {
+	Header  string
+	Notices []string
+	Columns emailHTMLColumns
+	Groups  []emailHTMLGroup
+}
+
+type emailHTMLColumns struct {
+	Domain         string
+	Status         string
+	ExpirationDate string
+}
+
+type emailHTMLGroup struct {
+	Title string
+	Rows  []emailHTMLRow
+}
+
+type emailHTMLRow struct {
+	Name           string
+	Status         string
+	ExpirationDate string
+	Color          template.CSS
+}
+
+// emailSubject executes the subject template with the report stats. An empty template
+// uses the default subject of the report language.
+func emailSubject(subjectTemplate string, rep report.Report) (string, error) {
+	if subjectTemplate == "" {
+		subjectTemplate = rep.Printer.T("email.subject")
+	}
+
+	tmpl, err := textTemplate.New("subject").Funcs(rep.Printer.TemplateFuncs()).Parse(subjectTemplate)
+	if err != nil {
+		return "", fmt.Errorf("email: invalid subject template: %w", err)
+	}
+
+	var subject strings.Builder
+	if err := tmpl.Execute(&subject, rep.Stats()); err != nil {
+		return "", fmt.Errorf("email: subject template failed: %w", err)
+	}
+
+	return strings.TrimSpace(subject.String()), nil
+}
+
+// buildEmailMessage returns a MIME message with plain-text and HTML alternatives
+// and, when requested, a CSV attachment with all checked domains.
+func (e *EmailChannel) buildEmailMessage(header string, rep report.Report) ([]byte, error) {
+	subject, err := emailSubject(e.subject, rep)
+	if err != nil {
+		return nil, err
+	}
+
+	htmlBody, err := emailHTML(header, rep)
+	if err != nil {
+		return nil, err
+	}
+
+	textBody := header + "\n\n" + strings.Join(rep.Lines(), "\n")
+
+	var buf bytes.Buffer
+	buf.WriteString("To: " + strings.Join(e.to, ", ") + "\r\n")
+	buf.WriteString("From: " + e.from + "\r\n")
+	buf.WriteString("Subject: " + mime.BEncoding.Encode("UTF-8", subject) + "\r\n")
+	buf.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	buf.WriteString("MIME-Version: 1.0\r\n")
+
+	alternative, err := alternativePart(textBody, htmlBody)
+	if err != nil {
+		return nil, err
+	}
+
+	if !e.attachCSV {
+		buf.WriteString("Content-Type: multipart/alternative; boundary=" + alternative.boundary + "\r\n\r\n")
+		buf.Write(alternative.body)
+		return buf.Bytes(), nil
+	}
+
+	csvBody, err := emailCSV(rep)
+	if err != nil {
+		return nil, err
+	}
+
+	var mixed bytes.Buffer
+	writer := multipart.NewWriter(&mixed)
+
+	part, err := writer.CreatePart(textproto.MIMEHeader{
+		"Content-Type": {"multipart/alternative; boundary=" + alternative.boundary},
+	})
+	if err != nil {
+		return nil, err
+	}
+	part.Write(alternative.body)
+
+	part, err = writer.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {`text/csv; charset=UTF-8; name="domains.csv"`},
+		"Content-Disposition":       {`attachment; filename="domains.csv"`},
+		"Content-Transfer-Encoding": {"base64"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	writeBase64Lines(part, csvBody)
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	buf.WriteString("Content-Type: multipart/mixed; boundary=" + writer.Boundary() + "\r\n\r\n")
+	buf.Write(mixed.Bytes())
+
+	return buf.Bytes(), nil
+}
+
+type mimePart struct {
+	boundary string
+	body     []byte
+}
+
+func alternativePart(textBody, htmlBody string) (mimePart, error) {
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+
+	for _, alternative := range []struct{ contentType, body string }{
+		{"text/plain; charset=UTF-8", textBody},
+		{"text/html; charset=UTF-8", htmlBody},
+	} {
+		part, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {alternative.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return mimePart{}, err
+		}
+
+		qp := quotedprintable.NewWriter(part)
+		if _, err := qp.Write([]byte(alternative.body)); err != nil {
+			return mimePart{}, err
+		}
+		if err := qp.Close(); err != nil {
+			return mimePart{}, err
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return mimePart{}, err
+	}
+
+	return mimePart{boundary: writer.Boundary(), body: buf.Bytes()}, nil
+}
+
+func emailHTML(header string, rep report.Report) (string, error) {
+	if rep.Template != nil {
+		out, err := rep.Template.Render(templateReport(header, rep))
+		if err != nil {
+			return "", err
+		}
+		return strings.Join(out.Blocks(), "\n"), nil
+	}
+
+	data := emailHTMLData{
+		Header:  strings.TrimSpace(header),
+		Notices: rep.NoticeLines(),
+		Columns: emailHTMLColumns{
+			Domain:         rep.Printer.T("email.domain"),
+			Status:         rep.Printer.T("email.status"),
+			ExpirationDate: rep.Printer.T("email.expiration"),
+		},
+	}
+
+	for _, group := range rep.Groups {
+		htmlGroup := emailHTMLGroup{Title: group.Title}
+		for _, domain := range group.Domains {
+			htmlGroup.Rows = append(htmlGroup.Rows, emailHTMLRow{
+				Name:           domain.Name,
+				Status:         domainStatus(rep.Printer, domain),
+				ExpirationDate: formatExpirationDate(domain),
+				Color:          template.CSS(emailRowColor(domain.GetSeverity())),
+			})
+		}
+		data.Groups = append(data.Groups, htmlGroup)
+	}
+
+	var buf bytes.Buffer
+	if err := emailHTMLTemplate.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("email: html template failed: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+// emailRowColor returns a light background color for a table row.
+func emailRowColor(severity api.Severity) string {
+	switch severity {
+	case api.SeverityWarning:
+		return "#fff4e5"
+	case api.SeverityError:
+		return "#fdecea"
+	case api.SeverityCritical:
+		return "#f8d7da"
+	default:
+		return "#e6f4ea"
+	}
+}
+
+func emailCSV(rep report.Report) ([]byte, error) {
+	groups := make(map[string]string)
+	for _, group := range rep.Groups {
+		for _, domain := range group.Domains {
+			groups[domain.Name] = group.Title
+		}
+	}
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+
+	rows := [][]string{{"domain", "group", "severity", "available", "days_left", "expiration_date", "error"}}
+	for _, domain := range rep.AllDomains() {
+		daysLeft, expirationDate := "", ""
+		if domain.ExpirationDate != nil {
+			daysLeft = strconv.FormatInt(domain.GetDaysToExpire(), 10)
+			expirationDate = domain.ExpiresAt().Format(time.RFC3339)
+		}
+
+		errorMessage := ""
+		if domain.Error != nil {
+			errorMessage = domain.Error.Error()
+		}
+
+		rows = append(rows, []string{
+			domain.Name,
+			groups[domain.Name],
+			domain.GetSeverity().String(),
+			strconv.FormatBool(domain.IsAvailable),
+			daysLeft,
+			expirationDate,
+			errorMessage,
+		})
+	}
+
+	if err := writer.WriteAll(rows); err != nil {
+		return nil, fmt.Errorf("email: csv failed: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// formatExpirationDate returns the exact expiration time in TIMEZONE, e.g. "2025-03-01 11:47 +05".
+func formatExpirationDate(domain api.Domain) string {
+	if domain.ExpirationDate == nil {
+		return ""
+	}
+	return domain.ExpiresAt().Format("2006-01-02 15:04 MST")
+}
+
+// writeBase64Lines writes base64 encoded data wrapped to 76 characters per line.
+func writeBase64Lines(w io.Writer, data []byte) {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	for len(encoded) > 76 {
+		w.Write([]byte(encoded[:76] + "\r\n"))
+		encoded = encoded[76:]
+	}
+	w.Write([]byte(encoded + "\r\n"))
+}
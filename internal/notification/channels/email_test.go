@@ -0,0 +1,150 @@
+package channels
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"github.com/Kravets1996/kz-domain-monitor/internal/api"
+	"github.com/Kravets1996/kz-domain-monitor/internal/report"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+)
+
+func getEmailReport() report.Report {
+	expiration := time.Now().AddDate(0, 0, 90)
+	expiring := time.Now().AddDate(0, 0, 3)
+
+	return report.Report{
+		Groups: []report.Group{{Title: "Сайты <prod>", Domains: []api.Domain{
+			{Name: "example.kz", ExpirationDate: &expiration, Policy: testPolicy},
+			{Name: "expiring.kz", ExpirationDate: &expiring, Policy: testPolicy},
+			{Name: "broken.kz", Error: errors.New("request status error: 500")},
+		}}},
+		HasError: true,
+	}
+}
+
+func TestEmailSubject(t *testing.T) {
+	subject, err := emailSubject(`{{.Expiring}} domains expiring, {{.Problems}} of {{.Total}} need attention`, getEmailReport())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if subject != "1 domains expiring, 2 of 3 need attention" {
+		t.Errorf("unexpected subject: %q", subject)
+	}
+}
+
+func TestEmailSubject_DefaultInLanguage(t *testing.T) {
+	subject, err := emailSubject("", getEmailReport().In("en"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if subject != "kz-domain-monitor: 2 of 3 domains need attention (expiring: 1, expired: 0, errors: 1)" {
+		t.Errorf("unexpected subject: %q", subject)
+	}
+}
+
+func TestEmailChannel_BuildMessage(t *testing.T) {
+	channel := NewEmailChannel(EmailOptions{
+		Host:      "smtp.example.kz",
+		Port:      "465",
+		From:      "monitor@example.kz",
+		To:        []string{"admin@example.kz"},
+		Subject:   "Домены: {{.Total}}",
+		AttachCSV: true,
+	})
+
+	body, err := channel.buildEmailMessage("Header", getEmailReport())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg, err := mail.ReadMessage(bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if subject != "Домены: 3" {
+		t.Errorf("unexpected subject: %q", subject)
+	}
+	if strings.Contains(msg.Header.Get("Subject"), "Домены") {
+		t.Error("subject should be RFC 2047 encoded")
+	}
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/mixed" {
+		t.Fatalf("unexpected content type: %s (%v)", mediaType, err)
+	}
+
+	parts := readParts(t, multipart.NewReader(msg.Body, params["boundary"]))
+	if len(parts) != 2 {
+		t.Fatalf("expected alternative part and attachment, got %d parts", len(parts))
+	}
+
+	mediaType, params, _ = mime.ParseMediaType(parts[0].Header.Get("Content-Type"))
+	if mediaType != "multipart/alternative" {
+		t.Fatalf("unexpected first part: %s", mediaType)
+	}
+
+	alternatives := readParts(t, multipart.NewReader(bytes.NewReader(parts[0].Body), params["boundary"]))
+	if len(alternatives) != 2 {
+		t.Fatalf("expected text and html alternatives, got %d", len(alternatives))
+	}
+	if !strings.Contains(string(alternatives[0].Body), "✅ 90 дней - example.kz") {
+		t.Errorf("unexpected plain text part: %s", alternatives[0].Body)
+	}
+	if !strings.Contains(string(alternatives[1].Body), "<b>Сайты &lt;prod&gt;</b>") {
+		t.Errorf("unexpected html part: %s", alternatives[1].Body)
+	}
+
+	if !strings.Contains(parts[1].Header.Get("Content-Disposition"), "domains.csv") {
+		t.Errorf("unexpected attachment: %v", parts[1].Header)
+	}
+	if !strings.Contains(string(parts[1].Body), "broken.kz,Сайты <prod>,error,false,,,request status error: 500") {
+		t.Errorf("unexpected csv: %s", parts[1].Body)
+	}
+}
+
+type mimeTestPart struct {
+	Header textproto.MIMEHeader
+	Body   []byte
+}
+
+// readParts reads all parts, decoding quoted-printable and base64 bodies.
+func readParts(t *testing.T, reader *multipart.Reader) []mimeTestPart {
+	t.Helper()
+
+	var parts []mimeTestPart
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return parts
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var body io.Reader = part
+		if part.Header.Get("Content-Transfer-Encoding") == "base64" {
+			body = base64.NewDecoder(base64.StdEncoding, part)
+		}
+
+		data, err := io.ReadAll(body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		parts = append(parts, mimeTestPart{Header: part.Header, Body: data})
+	}
+}
@@ -0,0 +1,49 @@
+package channels
+
+import (
+	"github.com/Kravets1996/kz-domain-monitor/internal/api"
+	"time"
+)
+
+// DomainEvent is the structured result of a domain check, published by the webhook and message brokers.
+type DomainEvent struct {
+	Domain         string     `json:"domain"`
+	Group          string     `json:"group,omitempty"`
+	Severity       string     `json:"severity"`
+	Available      bool       `json:"available"`
+	DaysLeft       *int64     `json:"daysLeft,omitempty"`
+	ExpirationDate *time.Time `json:"expirationDate,omitempty"`
+	Registrar      string     `json:"registrar,omitempty"`
+	Error          string     `json:"error,omitempty"`
+	Message        string     `json:"message"`
+	CheckedAt      time.Time  `json:"checkedAt"`
+}
+
+// NewDomainEvents converts check results to events. groupTitle returns the group of a domain.
+func NewDomainEvents(domains []api.Domain, groupTitle func(domain string) string) []DomainEvent {
+	checkedAt := time.Now().UTC().Truncate(time.Second)
+
+	events := make([]DomainEvent, 0, len(domains))
+	for _, domain := range domains {
+		event := DomainEvent{
+			Domain:    domain.Name,
+			Group:     groupTitle(domain.Name),
+			Severity:  domain.GetSeverity().String(),
+			Available: domain.IsAvailable,
+			Registrar: domain.Registrar,
+			Message:   domain.GetMessage(),
+			CheckedAt: checkedAt,
+		}
+		if domain.ExpirationDate != nil {
+			daysLeft := domain.GetDaysToExpire()
+			event.DaysLeft = &daysLeft
+			expiresAt := domain.ExpiresAt()
+			event.ExpirationDate = &expiresAt
+		}
+		if domain.Error != nil {
+			event.Error = domain.Error.Error()
+		}
+		events = append(events, event)
+	}
+	return events
+}
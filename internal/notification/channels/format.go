@@ -0,0 +1,162 @@
+package channels
+
+import (
+	"github.com/Kravets1996/kz-domain-monitor/internal/api"
+	"github.com/Kravets1996/kz-domain-monitor/internal/i18n"
+	"github.com/Kravets1996/kz-domain-monitor/internal/report"
+	"github.com/Kravets1996/kz-domain-monitor/internal/templates"
+	"strings"
+)
+
+// domainStatus returns the domain state without its name, e.g. "⚠️ 10 дней".
+func domainStatus(p i18n.Printer, domain api.Domain) string {
+	switch {
+	case domain.Error != nil:
+		return "❗️ " + domain.Error.Error()
+	case domain.IsAvailable:
+		return p.T("status.available")
+	case domain.ExpirationDate == nil:
+		return p.T("status.no_date")
+	}
+
+	return domain.GetIcon() + " " + domain.Days(p)
+}
+
+// severityColor returns the hex color used to highlight a severity level.
+func severityColor(severity api.Severity) string {
+	switch severity {
+	case api.SeverityWarning:
+		return "#daa038"
+	case api.SeverityError:
+		return "#e8912d"
+	case api.SeverityCritical:
+		return "#d40e0d"
+	default:
+		return "#2eb886"
+	}
+}
+
+// severityLabel returns a short human-readable description of a severity level.
+func severityLabel(p i18n.Printer, severity api.Severity) string {
+	return p.T("severity." + severity.String())
+}
+
+// noticeText returns the notices of the report, one per line.
+func noticeText(rep report.Report) string {
+	return strings.Join(rep.NoticeLines(), "\n")
+}
+
+// plainBlocks returns the notices and every group of the report as plain-text blocks.
+func plainBlocks(rep report.Report) []string {
+	var blocks []string
+	if notices := noticeText(rep); notices != "" {
+		blocks = append(blocks, notices)
+	}
+	for _, group := range rep.Groups {
+		if len(group.Domains) == 0 {
+			continue
+		}
+
+		var lines []string
+		if group.Title != "" {
+			lines = append(lines, group.Title+":")
+		}
+		for _, domain := range group.Domains {
+			lines = append(lines, domain.Message(rep.Printer))
+		}
+		blocks = append(blocks, strings.Join(lines, "\n"))
+	}
+	return blocks
+}
+
+// reportBlocks returns the header and the group blocks rendered with the channel template or,
+// when the report has no template, with the default layout of the channel.
+func reportBlocks(header string, rep report.Report, layout func(string, report.Report) []string) ([]string, error) {
+	if rep.Template == nil {
+		return layout(header, rep), nil
+	}
+
+	out, err := rep.Template.Render(templateReport(header, rep))
+	if err != nil {
+		return nil, err
+	}
+	return out.Blocks(), nil
+}
+
+// titledBlocks returns the title and the group blocks of channels that show the header as a title.
+func titledBlocks(header string, rep report.Report) (string, []string, error) {
+	if rep.Template == nil {
+		return strings.TrimSpace(header), plainBlocks(rep), nil
+	}
+
+	out, err := rep.Template.Render(templateReport(header, rep))
+	if err != nil {
+		return "", nil, err
+	}
+	return out.Header, out.Body, nil
+}
+
+// FormatPlainMessage renders the report as plain text: the header followed by the groups.
+func FormatPlainMessage(header string, rep report.Report) (string, error) {
+	blocks, err := reportBlocks(header, rep, func(header string, rep report.Report) []string {
+		return append([]string{header}, plainBlocks(rep)...)
+	})
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(blocks, "\n\n"), nil
+}
+
+// templateReport converts the report to the data of user templates.
+func templateReport(header string, rep report.Report) templates.Report {
+	stats := rep.Stats()
+	data := templates.Report{
+		Header:  strings.TrimSpace(header),
+		Notices: rep.NoticeLines(),
+		Stats: templates.Stats{
+			Total:    stats.Total,
+			Ok:       stats.Ok,
+			Expiring: stats.Expiring,
+			Expired:  stats.Expired,
+			Errors:   stats.Errors,
+			Problems: stats.Problems(),
+		},
+	}
+
+	for _, group := range rep.Groups {
+		templateGroup := templates.Group{Title: group.Title, Severity: group.Severity().String()}
+		for _, domain := range group.Domains {
+			templateGroup.Domains = append(templateGroup.Domains, templateDomain(rep.Printer, group.Title, domain))
+		}
+		data.Groups = append(data.Groups, templateGroup)
+	}
+
+	return data
+}
+
+func templateDomain(p i18n.Printer, group string, domain api.Domain) templates.Domain {
+	data := templates.Domain{
+		Name:        domain.Name,
+		Group:       group,
+		IsAvailable: domain.IsAvailable,
+		Registrar:   domain.Registrar,
+		Severity:    domain.GetSeverity().String(),
+		Icon:        "❗️",
+		Status:      domainStatus(p, domain),
+		Message:     domain.Message(p),
+	}
+
+	if domain.Error != nil {
+		data.Error = domain.Error.Error()
+	} else if domain.IsAvailable || domain.ExpirationDate != nil {
+		data.Icon = domain.GetIcon()
+	}
+
+	if domain.ExpirationDate != nil {
+		expiresAt := domain.ExpiresAt()
+		data.ExpirationDate = &expiresAt
+		data.DaysLeft = int(domain.GetDaysToExpire())
+	}
+
+	return data
+}
@@ -0,0 +1,49 @@
+package channels
+
+import (
+	"github.com/Kravets1996/kz-domain-monitor/internal/report"
+	"strings"
+)
+
+type GotifyChannel struct {
+	serverURL string
+	appToken  string
+	priority  int
+}
+
+type gotifyMessage struct {
+	Title    string `json:"title"`
+	Message  string `json:"message"`
+	Priority int    `json:"priority"`
+}
+
+// NewGotifyChannel creates a channel that posts messages with an application token.
+// The priority is used for reports with problems.
+func NewGotifyChannel(serverURL, appToken string, priority int) *GotifyChannel {
+	return &GotifyChannel{
+		serverURL: strings.TrimSuffix(serverURL, "/"),
+		appToken:  appToken,
+		priority:  priority,
+	}
+}
+
+// Send posts the report. Silent messages get priority 0, which Gotify clients show without a notification.
+func (g *GotifyChannel) Send(header string, rep report.Report, silent bool) error {
+	priority := g.priority
+	if silent {
+		priority = 0
+	}
+
+	title, blocks, err := titledBlocks(header, rep)
+	if err != nil {
+		return err
+	}
+
+	_, err = postJSON("gotify", g.serverURL+"/message", gotifyMessage{
+		Title:    title,
+		Message:  strings.Join(blocks, "\n\n"),
+		Priority: priority,
+	}, map[string]string{"X-Gotify-Key": g.appToken})
+
+	return err
+}
@@ -0,0 +1,37 @@
+package channels
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGotifyChannel_Send(t *testing.T) {
+	var message gotifyMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/message" || r.Header.Get("X-Gotify-Key") != "app-token" {
+			t.Errorf("unexpected request: %s %v", r.URL.Path, r.Header)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&message); err != nil {
+			t.Fatal(err)
+		}
+	}))
+	defer server.Close()
+
+	channel := NewGotifyChannel(server.URL+"/", "app-token", 8)
+
+	if err := channel.Send("Header", getChatReport(), false); err != nil {
+		t.Fatal(err)
+	}
+	if message.Priority != 8 || message.Title != "Header" {
+		t.Errorf("unexpected message: %+v", message)
+	}
+
+	if err := channel.Send("Header", getChatReport(), true); err != nil {
+		t.Fatal(err)
+	}
+	if message.Priority != 0 {
+		t.Errorf("silent messages should have priority 0, got %d", message.Priority)
+	}
+}
@@ -0,0 +1,62 @@
+package channels
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// postJSON sends payload as JSON and returns the body of a successful (2xx) response.
+// Errors are prefixed with the channel name.
+func postJSON(name, url string, payload any, headers map[string]string) ([]byte, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("%s: marshal failed: %w", name, err)
+	}
+
+	return sendRequest(name, http.MethodPost, url, "application/json", data, headers)
+}
+
+// sendRequest sends a request and returns the body of a successful (2xx) response.
+func sendRequest(name, method, url, contentType string, body []byte, headers map[string]string) ([]byte, error) {
+	client := &http.Client{
+		Timeout: 10 * time.Second,
+	}
+
+	ctx := context.Background()
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("%s: request creation failed: %w", name, err)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s: request failed: %w", name, err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		if err != nil {
+			return nil, fmt.Errorf("%s: unexpected status: %s (failed to read response body: %w)", name, resp.Status, err)
+		}
+		return nil, fmt.Errorf("%s: unexpected status: %s, body: %s", name, resp.Status, string(respBody))
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to read response body: %w", name, err)
+	}
+
+	return respBody, nil
+}
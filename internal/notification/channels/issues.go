@@ -0,0 +1,146 @@
+package channels
+
+import (
+	"fmt"
+	"github.com/Kravets1996/kz-domain-monitor/internal/api"
+	"github.com/Kravets1996/kz-domain-monitor/internal/i18n"
+	"github.com/Kravets1996/kz-domain-monitor/internal/state"
+	"strings"
+	"time"
+)
+
+const issuesIntegration = "issues"
+
+// issueTracker is implemented by the GitHub, GitLab and Jira clients.
+type issueTracker interface {
+	// createIssue opens an issue and returns its identifier: a number for GitHub and GitLab, a key for Jira.
+	createIssue(issue trackerIssue) (string, error)
+	commentIssue(id, text string) error
+	closeIssue(id, comment string) error
+}
+
+type trackerIssue struct {
+	Title    string
+	Body     string
+	Assignee string
+	Labels   []string
+}
+
+// IssuesChannel tracks domain renewals as issues: one issue per domain that crossed the warning threshold.
+type IssuesChannel struct {
+	tracker issueTracker
+	labels  []string
+	// integration is the state key of the open issues.
+	integration string
+	printer     i18n.Printer
+}
+
+// NewIssuesChannel creates a channel for tracker: "github", "gitlab" or "jira". project is "owner/repo" for GitHub,
+// a project path or ID for GitLab and a project key for Jira; username and issueType are used by Jira only.
+// Issues are written in lang.
+func NewIssuesChannel(tracker, url, username, token, project, issueType string, labels []string, lang string) (*IssuesChannel, error) {
+	var client issueTracker
+	switch tracker {
+	case "github":
+		client = newGitHubTracker(url, token, project)
+	case "gitlab":
+		client = newGitLabTracker(url, token, project)
+	case "jira":
+		client = newJiraTracker(url, username, token, project, issueType)
+	default:
+		return nil, fmt.Errorf("issues: unknown tracker %q", tracker)
+	}
+	return &IssuesChannel{tracker: client, labels: labels, integration: issuesIntegration, printer: i18n.New(lang)}, nil
+}
+
+// Sync opens an issue for every expiring, expired or available domain, comments on it when the number
+// of days left changes and closes it when the domain is renewed or no longer monitored.
+// Domains whose check failed keep their issue untouched. owner returns the assignee of a domain.
+func (c *IssuesChannel) Sync(domains []api.Domain, owner func(domain string) string, alerts *state.State) error {
+	checked := make(map[string]api.Domain, len(domains))
+
+	for _, domain := range domains {
+		checked[domain.Name] = domain
+
+		severity := domain.GetSeverity()
+		if severity != api.SeverityWarning && severity != api.SeverityCritical {
+			continue
+		}
+
+		alert := state.Alert{Severity: severity.String(), UpdatedAt: time.Now()}
+		if domain.ExpirationDate != nil {
+			alert.DaysLeft = domain.GetDaysToExpire()
+		}
+
+		existing, ok := alerts.Get(c.integration, domain.Name)
+		if !ok {
+			id, err := c.tracker.createIssue(trackerIssue{
+				Title:    c.printer.T("issue.title", domain.Name),
+				Body:     issueBody(c.printer, domain),
+				Assignee: owner(domain.Name),
+				Labels:   c.labels,
+			})
+			if err != nil {
+				return err
+			}
+
+			alert.ID = id
+			alerts.Set(c.integration, domain.Name, alert)
+			continue
+		}
+
+		if existing.DaysLeft == alert.DaysLeft && existing.Severity == alert.Severity {
+			continue
+		}
+
+		if err := c.tracker.commentIssue(existing.ID, domain.Message(c.printer)); err != nil {
+			return err
+		}
+
+		alert.ID = existing.ID
+		alerts.Set(c.integration, domain.Name, alert)
+	}
+
+	for _, name := range alerts.Domains(c.integration) {
+		domain, ok := checked[name]
+		if ok && domain.GetSeverity() != api.SeverityOk {
+			continue
+		}
+
+		comment := c.printer.T("issue.unmonitored")
+		if ok {
+			comment = c.printer.T("issue.renewed", formatExpirationDate(domain))
+		}
+
+		alert, _ := alerts.Get(c.integration, name)
+		if err := c.tracker.closeIssue(alert.ID, comment); err != nil {
+			return err
+		}
+
+		alerts.Delete(c.integration, name)
+	}
+
+	return nil
+}
+
+// issueBody describes the domain in plain text, which renders well as Markdown and Jira wiki markup.
+func issueBody(p i18n.Printer, domain api.Domain) string {
+	lines := []string{p.T("issue.domain", domain.Name)}
+
+	if domain.IsAvailable {
+		lines = append(lines, p.T("issue.available"))
+	}
+	if domain.ExpirationDate != nil {
+		lines = append(lines, p.T("issue.expiration", formatExpirationDate(domain)))
+		if domain.GetDaysToExpire() > 0 {
+			lines = append(lines, p.T("issue.days_left", domain.Days(p)))
+		} else {
+			lines = append(lines, p.T("issue.status", domain.Days(p)))
+		}
+	}
+	if domain.Registrar != "" {
+		lines = append(lines, p.T("issue.registrar", domain.Registrar))
+	}
+
+	return strings.Join(lines, "\n\n")
+}
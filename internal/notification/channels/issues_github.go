@@ -0,0 +1,75 @@
+package channels
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type gitHubTracker struct {
+	apiURL string
+	token  string
+	repo   string
+}
+
+func newGitHubTracker(apiURL, token, repo string) *gitHubTracker {
+	return &gitHubTracker{apiURL: strings.TrimSuffix(apiURL, "/"), token: token, repo: repo}
+}
+
+func (g *gitHubTracker) createIssue(issue trackerIssue) (string, error) {
+	payload := map[string]any{
+		"title":  issue.Title,
+		"body":   issue.Body,
+		"labels": issue.Labels,
+	}
+	if issue.Assignee != "" {
+		payload["assignees"] = []string{issue.Assignee}
+	}
+
+	body, err := postJSON("github", g.issuesURL(), payload, g.headers())
+	if err != nil {
+		return "", err
+	}
+
+	var created struct {
+		Number int `json:"number"`
+	}
+	if err := json.Unmarshal(body, &created); err != nil {
+		return "", fmt.Errorf("github: failed to parse response: %w", err)
+	}
+
+	return strconv.Itoa(created.Number), nil
+}
+
+func (g *gitHubTracker) commentIssue(id, text string) error {
+	_, err := postJSON("github", g.issuesURL()+"/"+id+"/comments", map[string]string{"body": text}, g.headers())
+	return err
+}
+
+func (g *gitHubTracker) closeIssue(id, comment string) error {
+	if err := g.commentIssue(id, comment); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(map[string]string{"state": "closed", "state_reason": "completed"})
+	if err != nil {
+		return fmt.Errorf("github: marshal failed: %w", err)
+	}
+
+	_, err = sendRequest("github", http.MethodPatch, g.issuesURL()+"/"+id, "application/json", data, g.headers())
+	return err
+}
+
+func (g *gitHubTracker) issuesURL() string {
+	return g.apiURL + "/repos/" + g.repo + "/issues"
+}
+
+func (g *gitHubTracker) headers() map[string]string {
+	return map[string]string{
+		"Authorization":        "Bearer " + g.token,
+		"Accept":               "application/vnd.github+json",
+		"X-GitHub-Api-Version": "2022-11-28",
+	}
+}
@@ -0,0 +1,96 @@
+package channels
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+type gitLabTracker struct {
+	baseURL string
+	token   string
+	project string
+}
+
+func newGitLabTracker(baseURL, token, project string) *gitLabTracker {
+	return &gitLabTracker{baseURL: strings.TrimSuffix(baseURL, "/"), token: token, project: project}
+}
+
+func (g *gitLabTracker) createIssue(issue trackerIssue) (string, error) {
+	payload := map[string]any{
+		"title":       issue.Title,
+		"description": issue.Body,
+		"labels":      strings.Join(issue.Labels, ","),
+	}
+	if issue.Assignee != "" {
+		userID, err := g.userID(issue.Assignee)
+		if err != nil {
+			return "", err
+		}
+		payload["assignee_ids"] = []int{userID}
+	}
+
+	body, err := postJSON("gitlab", g.issuesURL(), payload, g.headers())
+	if err != nil {
+		return "", err
+	}
+
+	var created struct {
+		IID int `json:"iid"`
+	}
+	if err := json.Unmarshal(body, &created); err != nil {
+		return "", fmt.Errorf("gitlab: failed to parse response: %w", err)
+	}
+
+	return strconv.Itoa(created.IID), nil
+}
+
+func (g *gitLabTracker) commentIssue(id, text string) error {
+	_, err := postJSON("gitlab", g.issuesURL()+"/"+id+"/notes", map[string]string{"body": text}, g.headers())
+	return err
+}
+
+func (g *gitLabTracker) closeIssue(id, comment string) error {
+	if err := g.commentIssue(id, comment); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(map[string]string{"state_event": "close"})
+	if err != nil {
+		return fmt.Errorf("gitlab: marshal failed: %w", err)
+	}
+
+	_, err = sendRequest("gitlab", http.MethodPut, g.issuesURL()+"/"+id, "application/json", data, g.headers())
+	return err
+}
+
+// userID resolves a username from the domain config to the numeric ID expected by assignee_ids.
+func (g *gitLabTracker) userID(username string) (int, error) {
+	body, err := sendRequest("gitlab", http.MethodGet, g.baseURL+"/api/v4/users?username="+url.QueryEscape(username), "", nil, g.headers())
+	if err != nil {
+		return 0, err
+	}
+
+	var users []struct {
+		ID int `json:"id"`
+	}
+	if err := json.Unmarshal(body, &users); err != nil {
+		return 0, fmt.Errorf("gitlab: failed to parse users: %w", err)
+	}
+	if len(users) == 0 {
+		return 0, fmt.Errorf("gitlab: user %s not found", username)
+	}
+
+	return users[0].ID, nil
+}
+
+func (g *gitLabTracker) issuesURL() string {
+	return g.baseURL + "/api/v4/projects/" + url.PathEscape(g.project) + "/issues"
+}
+
+func (g *gitLabTracker) headers() map[string]string {
+	return map[string]string{"PRIVATE-TOKEN": g.token}
+}
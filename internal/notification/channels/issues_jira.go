@@ -0,0 +1,112 @@
+package channels
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+type jiraTracker struct {
+	baseURL   string
+	username  string
+	token     string
+	project   string
+	issueType string
+}
+
+type jiraTransitions struct {
+	Transitions []struct {
+		ID string `json:"id"`
+		To struct {
+			StatusCategory struct {
+				Key string `json:"key"`
+			} `json:"statusCategory"`
+		} `json:"to"`
+	} `json:"transitions"`
+}
+
+func newJiraTracker(baseURL, username, token, project, issueType string) *jiraTracker {
+	return &jiraTracker{
+		baseURL:   strings.TrimSuffix(baseURL, "/"),
+		username:  username,
+		token:     token,
+		project:   project,
+		issueType: issueType,
+	}
+}
+
+// createIssue uses REST API v2, which accepts plain-text descriptions. The assignee is an account ID.
+func (j *jiraTracker) createIssue(issue trackerIssue) (string, error) {
+	fields := map[string]any{
+		"project":     map[string]string{"key": j.project},
+		"summary":     issue.Title,
+		"description": issue.Body,
+		"issuetype":   map[string]string{"name": j.issueType},
+		"labels":      issue.Labels,
+	}
+	if issue.Assignee != "" {
+		fields["assignee"] = map[string]string{"accountId": issue.Assignee}
+	}
+
+	body, err := postJSON("jira", j.baseURL+"/rest/api/2/issue", map[string]any{"fields": fields}, j.headers())
+	if err != nil {
+		return "", err
+	}
+
+	var created struct {
+		Key string `json:"key"`
+	}
+	if err := json.Unmarshal(body, &created); err != nil {
+		return "", fmt.Errorf("jira: failed to parse response: %w", err)
+	}
+
+	return created.Key, nil
+}
+
+func (j *jiraTracker) commentIssue(id, text string) error {
+	_, err := postJSON("jira", j.issueURL(id)+"/comment", map[string]string{"body": text}, j.headers())
+	return err
+}
+
+// closeIssue moves the issue with the first transition leading to a "done" status category,
+// so it works with custom workflows.
+func (j *jiraTracker) closeIssue(id, comment string) error {
+	if err := j.commentIssue(id, comment); err != nil {
+		return err
+	}
+
+	body, err := sendRequest("jira", http.MethodGet, j.issueURL(id)+"/transitions", "", nil, j.headers())
+	if err != nil {
+		return err
+	}
+
+	var transitions jiraTransitions
+	if err := json.Unmarshal(body, &transitions); err != nil {
+		return fmt.Errorf("jira: failed to parse transitions: %w", err)
+	}
+
+	for _, transition := range transitions.Transitions {
+		if transition.To.StatusCategory.Key == "done" {
+			payload := map[string]any{"transition": map[string]string{"id": transition.ID}}
+			_, err := postJSON("jira", j.issueURL(id)+"/transitions", payload, j.headers())
+			return err
+		}
+	}
+
+	return fmt.Errorf("jira: no transition to a done status for %s", id)
+}
+
+func (j *jiraTracker) issueURL(id string) string {
+	return j.baseURL + "/rest/api/2/issue/" + id
+}
+
+// headers uses basic auth with an API token for Jira Cloud and a personal access token otherwise.
+func (j *jiraTracker) headers() map[string]string {
+	if j.username != "" {
+		credentials := base64.StdEncoding.EncodeToString([]byte(j.username + ":" + j.token))
+		return map[string]string{"Authorization": "Basic " + credentials}
+	}
+	return map[string]string{"Authorization": "Bearer " + j.token}
+}
@@ -0,0 +1,128 @@
+package channels
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/Kravets1996/kz-domain-monitor/internal/api"
+	"github.com/Kravets1996/kz-domain-monitor/internal/state"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestIssuesChannel_SyncGitHub(t *testing.T) {
+	var requests []string
+	var created map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		if r.Header.Get("Authorization") != "Bearer token" {
+			t.Errorf("unexpected authorization header: %q", r.Header.Get("Authorization"))
+		}
+		if r.Method == http.MethodPost && r.URL.Path == "/repos/acme/billing/issues" {
+			if err := json.NewDecoder(r.Body).Decode(&created); err != nil {
+				t.Fatal(err)
+			}
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"number": 42}`)
+			return
+		}
+		fmt.Fprint(w, `{}`)
+	}))
+	defer server.Close()
+
+	alerts, err := state.Load(filepath.Join(t.TempDir(), "state.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	channel, err := NewIssuesChannel("github", server.URL, "", "token", "acme/billing", "", []string{"kz-domain-monitor"}, "ru")
+	if err != nil {
+		t.Fatal(err)
+	}
+	owner := func(string) string { return "octocat" }
+
+	expiring := time.Now().AddDate(0, 0, 4)
+	sooner := time.Now().AddDate(0, 0, 3)
+	renewed := time.Now().AddDate(0, 0, 365)
+
+	domain := api.Domain{Name: "example.kz", ExpirationDate: &expiring, Policy: testPolicy, Registrar: "PS Internet Company"}
+	if err := channel.Sync([]api.Domain{domain}, owner, alerts); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(requests) != 1 || created["title"] != "Продлить домен example.kz" {
+		t.Fatalf("expected issue creation, got %v %v", requests, created)
+	}
+	if body, _ := created["body"].(string); !strings.Contains(body, "PS Internet Company") || !strings.Contains(body, "Осталось: 4 дня") {
+		t.Errorf("unexpected issue body: %q", body)
+	}
+	if assignees, _ := created["assignees"].([]any); len(assignees) != 1 || assignees[0] != "octocat" {
+		t.Errorf("unexpected assignees: %v", created["assignees"])
+	}
+
+	// The same number of days left does not add a comment.
+	requests = nil
+	if err := channel.Sync([]api.Domain{domain}, owner, alerts); err != nil {
+		t.Fatal(err)
+	}
+	if len(requests) != 0 {
+		t.Errorf("unchanged domain should not be commented: %v", requests)
+	}
+
+	domain.ExpirationDate = &sooner
+	if err := channel.Sync([]api.Domain{domain}, owner, alerts); err != nil {
+		t.Fatal(err)
+	}
+	if len(requests) != 1 || requests[0] != "POST /repos/acme/billing/issues/42/comments" {
+		t.Errorf("expected comment, got %v", requests)
+	}
+
+	requests = nil
+	domain.ExpirationDate = &renewed
+	if err := channel.Sync([]api.Domain{domain}, owner, alerts); err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"POST /repos/acme/billing/issues/42/comments", "PATCH /repos/acme/billing/issues/42"}
+	if strings.Join(requests, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected %v, got %v", expected, requests)
+	}
+	if len(alerts.Domains(issuesIntegration)) != 0 {
+		t.Error("closed issue should be removed from state")
+	}
+}
+
+func TestJiraTracker_CloseIssue(t *testing.T) {
+	var transition string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/rest/api/2/issue/OPS-1/transitions":
+			fmt.Fprint(w, `{"transitions": [
+				{"id": "11", "to": {"statusCategory": {"key": "indeterminate"}}},
+				{"id": "31", "to": {"statusCategory": {"key": "done"}}}
+			]}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/rest/api/2/issue/OPS-1/transitions":
+			body, _ := io.ReadAll(r.Body)
+			transition = string(body)
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodPost && r.URL.Path == "/rest/api/2/issue/OPS-1/comment":
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{}`)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	tracker := newJiraTracker(server.URL, "user@example.kz", "token", "OPS", "Task")
+	if err := tracker.closeIssue("OPS-1", "Домен продлён"); err != nil {
+		t.Fatal(err)
+	}
+
+	if transition != `{"transition":{"id":"31"}}` {
+		t.Errorf("expected done transition, got %s", transition)
+	}
+}
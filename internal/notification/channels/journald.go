@@ -0,0 +1,80 @@
+package channels
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"github.com/Kravets1996/kz-domain-monitor/internal/api"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// journaldSocket is the socket of the systemd journal native protocol.
+const journaldSocket = "/run/systemd/journal/socket"
+
+type JournaldChannel struct {
+	socket     string
+	identifier string
+}
+
+// NewJournaldChannel creates a channel that writes entries with native journal fields.
+func NewJournaldChannel(identifier string) *JournaldChannel {
+	return &JournaldChannel{socket: journaldSocket, identifier: identifier}
+}
+
+// Available reports whether the journal socket exists, e.g. when running under systemd.
+func (j *JournaldChannel) Available() bool {
+	_, err := os.Stat(j.socket)
+	return !errors.Is(err, os.ErrNotExist)
+}
+
+// Send writes one journal entry per domain result. The fields can be filtered with
+// journalctl, e.g. journalctl DOMAIN_SEVERITY=critical.
+func (j *JournaldChannel) Send(domains []api.Domain) error {
+	conn, err := net.Dial("unixgram", j.socket)
+	if err != nil {
+		return fmt.Errorf("journald: dial failed: %w", err)
+	}
+	defer conn.Close()
+
+	for _, domain := range domains {
+		if _, err := conn.Write(journaldEntry(domain, j.identifier)); err != nil {
+			return fmt.Errorf("journald: write failed: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// journaldEntry serializes the fields in the native protocol format.
+func journaldEntry(domain api.Domain, identifier string) []byte {
+	var entry bytes.Buffer
+
+	writeJournaldField(&entry, "MESSAGE", domain.GetMessage())
+	writeJournaldField(&entry, "PRIORITY", strconv.Itoa(syslogSeverity(domain.GetSeverity())))
+	writeJournaldField(&entry, "SYSLOG_IDENTIFIER", identifier)
+	for _, field := range domainFields(domain) {
+		key := field.key
+		if key != "DOMAIN" {
+			key = "DOMAIN_" + key
+		}
+		writeJournaldField(&entry, key, field.value)
+	}
+
+	return entry.Bytes()
+}
+
+// writeJournaldField writes KEY=value, or a length-prefixed value when it contains a newline.
+func writeJournaldField(entry *bytes.Buffer, key, value string) {
+	if !strings.Contains(value, "\n") {
+		entry.WriteString(key + "=" + value + "\n")
+		return
+	}
+
+	entry.WriteString(key + "\n")
+	binary.Write(entry, binary.LittleEndian, uint64(len(value)))
+	entry.WriteString(value + "\n")
+}
@@ -0,0 +1,56 @@
+package channels
+
+import (
+	"encoding/binary"
+	"errors"
+	"github.com/Kravets1996/kz-domain-monitor/internal/api"
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestJournaldChannel_Send(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "journal.socket")
+	conn, err := net.ListenPacket("unixgram", socket)
+	if err != nil {
+		t.Skip("unix datagram sockets are not supported:", err)
+	}
+	defer conn.Close()
+
+	channel := &JournaldChannel{socket: socket, identifier: "kz-domain-monitor"}
+	if !channel.Available() {
+		t.Fatal("socket should be available")
+	}
+
+	expired := time.Now().AddDate(0, 0, -5)
+	if err := channel.Send([]api.Domain{{Name: "expired.kz", ExpirationDate: &expired, Policy: testPolicy}}); err != nil {
+		t.Fatal(err)
+	}
+
+	buf := make([]byte, 2048)
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	n, _, err := conn.ReadFrom(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entry := string(buf[:n])
+	for _, field := range []string{"PRIORITY=2\n", "SYSLOG_IDENTIFIER=kz-domain-monitor\n", "DOMAIN=expired.kz\n", "DOMAIN_SEVERITY=critical\n", "DOMAIN_DAYS_LEFT=-5\n"} {
+		if !strings.Contains(entry, field) {
+			t.Errorf("entry does not contain %q:\n%s", field, entry)
+		}
+	}
+}
+
+func TestJournaldEntry_MultilineValue(t *testing.T) {
+	entry := string(journaldEntry(api.Domain{Name: "broken.kz", Error: errors.New("line1\nline2")}, "app"))
+
+	length := make([]byte, 8)
+	binary.LittleEndian.PutUint64(length, uint64(len("line1\nline2")))
+
+	if !strings.Contains(entry, "DOMAIN_ERROR\n"+string(length)+"line1\nline2\n") {
+		t.Errorf("multiline value should be length-prefixed: %q", entry)
+	}
+}
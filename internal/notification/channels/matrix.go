@@ -0,0 +1,155 @@
+package channels
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/Kravets1996/kz-domain-monitor/internal/api"
+	"github.com/Kravets1996/kz-domain-monitor/internal/i18n"
+	"github.com/Kravets1996/kz-domain-monitor/internal/report"
+	"html"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// matrixContentLimit is the maximum size of the marshalled message content. It leaves room for the
+// event envelope (sender, room, signatures) within the 64 KiB Matrix event size limit.
+const matrixContentLimit = 60 * 1024
+
+type MatrixChannel struct {
+	homeserverURL string
+	accessToken   string
+	roomID        string
+}
+
+type matrixMessage struct {
+	MsgType       string `json:"msgtype"`
+	Body          string `json:"body"`
+	Format        string `json:"format"`
+	FormattedBody string `json:"formatted_body"`
+}
+
+// NewMatrixChannel creates a channel that sends messages to a room via the client-server API.
+func NewMatrixChannel(homeserverURL, accessToken, roomID string) *MatrixChannel {
+	return &MatrixChannel{
+		homeserverURL: strings.TrimSuffix(homeserverURL, "/"),
+		accessToken:   accessToken,
+		roomID:        roomID,
+	}
+}
+
+// Send posts the report as m.notice messages with a plain body and an HTML formatted_body.
+func (m *MatrixChannel) Send(header string, rep report.Report) error {
+	for i, message := range matrixMessages(header, rep) {
+		txnID := fmt.Sprintf("kz-domain-monitor-%d-%d", time.Now().UnixNano(), i)
+		endpoint := fmt.Sprintf("%s/_matrix/client/v3/rooms/%s/send/m.room.message/%s", m.homeserverURL, url.PathEscape(m.roomID), txnID)
+
+		payload, err := json.Marshal(message)
+		if err != nil {
+			return fmt.Errorf("matrix: marshal failed: %w", err)
+		}
+
+		_, err = sendRequest("matrix", http.MethodPut, endpoint, "application/json", payload, map[string]string{
+			"Authorization": "Bearer " + m.accessToken,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// matrixMessages renders the header and groups into messages whose marshalled content fits into
+// matrixContentLimit. A group that does not fit is continued in the next message under its title.
+func matrixMessages(header string, rep report.Report) []matrixMessage {
+	var messages []matrixMessage
+
+	current := matrixMessage{
+		MsgType:       "m.notice",
+		Format:        "org.matrix.custom.html",
+		Body:          strings.TrimSpace(header),
+		FormattedBody: "<p><b>" + html.EscapeString(strings.TrimSpace(header)) + "</b></p>",
+	}
+	if notices := noticeText(rep); notices != "" {
+		current.Body += "\n\n" + notices
+		current.FormattedBody += "<p>" + strings.ReplaceAll(html.EscapeString(notices), "\n", "<br>") + "</p>"
+	}
+
+	for _, group := range rep.Groups {
+		var part []api.Domain
+
+		// size is an upper bound of the content size with the part appended: JSON escaping is applied
+		// to every character separately, so the sizes of the rendered pieces add up.
+		base := matrixContentSize(current) + matrixJSONSize("\n\n"+group.Title+":") + matrixJSONSize("<p><b>"+html.EscapeString(group.Title)+":</b></p>")
+		size := base
+
+		for _, domain := range group.Domains {
+			lineSize := matrixJSONSize("\n"+domain.Message(rep.Printer)) + matrixJSONSize("<br>"+formatTelegramDomain(rep.Printer, domain))
+
+			if size+lineSize > matrixContentLimit && (len(part) > 0 || current.Body != "") {
+				messages = append(messages, withMatrixGroup(rep.Printer, current, report.Group{Title: group.Title, Domains: part}))
+				current = matrixMessage{MsgType: "m.notice", Format: "org.matrix.custom.html"}
+				part = nil
+
+				base = matrixContentSize(current) + matrixJSONSize(group.Title+":") + matrixJSONSize("<p><b>"+html.EscapeString(group.Title)+":</b></p>")
+				size = base
+			}
+
+			part = append(part, domain)
+			size += lineSize
+		}
+
+		current = withMatrixGroup(rep.Printer, current, report.Group{Title: group.Title, Domains: part})
+	}
+
+	return append(messages, current)
+}
+
+// withMatrixGroup returns the message with the group appended. Groups without domains are skipped.
+func withMatrixGroup(p i18n.Printer, message matrixMessage, group report.Group) matrixMessage {
+	if len(group.Domains) == 0 {
+		return message
+	}
+
+	body, formattedBody := matrixGroup(p, group)
+	if message.Body != "" {
+		message.Body += "\n\n"
+	}
+	message.Body += body
+	message.FormattedBody += formattedBody
+
+	return message
+}
+
+// matrixContentSize returns the size of the message as sent, with HTML characters escaped by json.Marshal.
+func matrixContentSize(message matrixMessage) int {
+	payload, _ := json.Marshal(message)
+	return len(payload)
+}
+
+// matrixJSONSize returns the size of s in a marshalled JSON string, without the quotes.
+func matrixJSONSize(s string) int {
+	payload, _ := json.Marshal(s)
+	return len(payload) - 2
+}
+
+// matrixGroup returns the plain and HTML representation of a group.
+func matrixGroup(p i18n.Printer, group report.Group) (string, string) {
+	var (
+		lines     []string
+		htmlLines []string
+	)
+
+	if group.Title != "" {
+		lines = append(lines, group.Title+":")
+		htmlLines = append(htmlLines, "<b>"+html.EscapeString(group.Title)+":</b>")
+	}
+
+	for _, domain := range group.Domains {
+		lines = append(lines, domain.Message(p))
+		htmlLines = append(htmlLines, formatTelegramDomain(p, domain))
+	}
+
+	return strings.Join(lines, "\n"), "<p>" + strings.Join(htmlLines, "<br>") + "</p>"
+}
@@ -0,0 +1,76 @@
+package channels
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/Kravets1996/kz-domain-monitor/internal/api"
+	"github.com/Kravets1996/kz-domain-monitor/internal/report"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMatrixChannel_Send(t *testing.T) {
+	var message matrixMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("expected PUT, got %s", r.Method)
+		}
+		if !strings.HasPrefix(r.URL.EscapedPath(), "/_matrix/client/v3/rooms/%21room:example.kz/send/m.room.message/") {
+			t.Errorf("unexpected path: %s", r.URL.EscapedPath())
+		}
+		if r.Header.Get("Authorization") != "Bearer token" {
+			t.Errorf("unexpected authorization: %s", r.Header.Get("Authorization"))
+		}
+		if err := json.NewDecoder(r.Body).Decode(&message); err != nil {
+			t.Fatal(err)
+		}
+		w.Write([]byte(`{"event_id": "$event"}`))
+	}))
+	defer server.Close()
+
+	if err := NewMatrixChannel(server.URL+"/", "token", "!room:example.kz").Send("Header", getChatReport()); err != nil {
+		t.Fatal(err)
+	}
+
+	if message.MsgType != "m.notice" || message.Format != "org.matrix.custom.html" {
+		t.Errorf("unexpected message type: %+v", message)
+	}
+	if !strings.Contains(message.Body, "Сайты:\n✅ 90 дней - example.kz") {
+		t.Errorf("unexpected body: %q", message.Body)
+	}
+	if !strings.Contains(message.FormattedBody, "<b>Сайты:</b><br>✅ <code>90 дней</code> - example.kz") {
+		t.Errorf("unexpected formatted body: %q", message.FormattedBody)
+	}
+}
+
+func TestMatrixMessages_EventSize(t *testing.T) {
+	expiration := time.Now().AddDate(0, 0, 90)
+
+	var domains []api.Domain
+	for i := 0; i < 3000; i++ {
+		domains = append(domains, api.Domain{Name: fmt.Sprintf("domain-%04d.kz", i), ExpirationDate: &expiration, Policy: testPolicy})
+	}
+	rep := report.Report{Groups: []report.Group{{Title: "Сайты", Domains: domains}}}
+
+	messages := matrixMessages("Header", rep)
+	if len(messages) < 2 {
+		t.Fatalf("expected the report to be split, got %d message", len(messages))
+	}
+
+	count := 0
+	for _, message := range messages {
+		if size := matrixContentSize(message); size > matrixContentLimit {
+			t.Errorf("message content of %d bytes exceeds the limit", size)
+		}
+		if !strings.HasPrefix(message.Body, "Header") && !strings.HasPrefix(message.Body, "Сайты:") {
+			t.Errorf("continued message should repeat the group title: %q", message.Body[:20])
+		}
+		count += strings.Count(message.Body, ".kz")
+	}
+	if count != len(domains) {
+		t.Errorf("expected %d domains in messages, got %d", len(domains), count)
+	}
+}
@@ -0,0 +1,59 @@
+package channels
+
+import (
+	"github.com/Kravets1996/kz-domain-monitor/internal/report"
+	"strings"
+)
+
+// mattermostMessageLimit is the maximum number of characters in a Mattermost post.
+const mattermostMessageLimit = 16383
+
+type MattermostChannel struct {
+	webhookURL string
+	channel    string
+	username   string
+	iconURL    string
+}
+
+type mattermostMessage struct {
+	Text        string           `json:"text"`
+	Channel     string           `json:"channel,omitempty"`
+	Username    string           `json:"username,omitempty"`
+	IconURL     string           `json:"icon_url,omitempty"`
+	Attachments []chatAttachment `json:"attachments,omitempty"`
+}
+
+// NewMattermostChannel creates a channel for a Mattermost incoming webhook.
+// Empty channel, username and iconURL keep the webhook defaults.
+func NewMattermostChannel(webhookURL, channel, username, iconURL string) *MattermostChannel {
+	return &MattermostChannel{
+		webhookURL: webhookURL,
+		channel:    channel,
+		username:   username,
+		iconURL:    iconURL,
+	}
+}
+
+// Send posts the report header as text and a colored attachment per group, split into several posts
+// to respect the Mattermost message limit.
+func (m *MattermostChannel) Send(header string, rep report.Report) error {
+	text := chatText("#### "+strings.TrimSpace(header), rep)
+
+	for i, attachments := range packChatAttachments(text, chatAttachments(rep, mattermostMessageLimit), mattermostMessageLimit) {
+		message := mattermostMessage{
+			Channel:     m.channel,
+			Username:    m.username,
+			IconURL:     m.iconURL,
+			Attachments: attachments,
+		}
+		if i == 0 {
+			message.Text = text
+		}
+
+		if _, err := postJSON("mattermost", m.webhookURL, message, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+package channels
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/Kravets1996/kz-domain-monitor/internal/api"
+	"github.com/Kravets1996/kz-domain-monitor/internal/report"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func getChatReport() report.Report {
+	expiration := time.Now().AddDate(0, 0, 90)
+	expiring := time.Now().AddDate(0, 0, 3)
+
+	return report.Report{Groups: []report.Group{{Title: "Сайты", Domains: []api.Domain{
+		{Name: "example.kz", ExpirationDate: &expiration, Policy: testPolicy},
+		{Name: "expiring.kz", ExpirationDate: &expiring, Policy: testPolicy},
+	}}}}
+}
+
+func TestMattermostChannel_Send(t *testing.T) {
+	var message mattermostMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&message); err != nil {
+			t.Fatal(err)
+		}
+	}))
+	defer server.Close()
+
+	if err := NewMattermostChannel(server.URL, "town-square", "kz-domain-monitor", "").Send("Header ", getChatReport()); err != nil {
+		t.Fatal(err)
+	}
+
+	if message.Text != "#### Header" || message.Channel != "town-square" || message.Username != "kz-domain-monitor" {
+		t.Errorf("unexpected message: %+v", message)
+	}
+	if len(message.Attachments) != 1 {
+		t.Fatalf("expected 1 attachment, got %d", len(message.Attachments))
+	}
+
+	attachment := message.Attachments[0]
+	if attachment.Color != severityColor(api.SeverityWarning) || attachment.Title != "Сайты" {
+		t.Errorf("unexpected attachment: %+v", attachment)
+	}
+	if attachment.Fields[1] != (chatField{Title: "expiring.kz", Value: "⚠️ 3 дня", Short: true}) {
+		t.Errorf("unexpected field: %+v", attachment.Fields[1])
+	}
+	if attachment.Fallback == "" {
+		t.Error("attachment should have a fallback text")
+	}
+}
+
+func TestMattermostChannel_Send_Split(t *testing.T) {
+	var messages []mattermostMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var message mattermostMessage
+		if err := json.NewDecoder(r.Body).Decode(&message); err != nil {
+			t.Fatal(err)
+		}
+		messages = append(messages, message)
+	}))
+	defer server.Close()
+
+	expiration := time.Now().AddDate(0, 0, 90)
+	var domains []api.Domain
+	for i := 0; i < 1000; i++ {
+		domains = append(domains, api.Domain{Name: fmt.Sprintf("domain-%04d.kz", i), ExpirationDate: &expiration, Policy: testPolicy})
+	}
+	rep := report.Report{Groups: []report.Group{{Title: "Сайты", Domains: domains}}}
+
+	if err := NewMattermostChannel(server.URL, "", "", "").Send("Header", rep); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(messages) < 2 {
+		t.Fatalf("expected the report to be split, got %d post", len(messages))
+	}
+	if messages[0].Text != "#### Header" || messages[1].Text != "" {
+		t.Errorf("only the first post should have the header: %q, %q", messages[0].Text, messages[1].Text)
+	}
+
+	count := 0
+	for _, message := range messages {
+		size := len([]rune(message.Text))
+		for _, attachment := range message.Attachments {
+			size += chatAttachmentSize(attachment)
+			count += len(attachment.Fields)
+		}
+		if size > mattermostMessageLimit {
+			t.Errorf("post of %d characters exceeds the limit", size)
+		}
+	}
+	if count != len(domains) {
+		t.Errorf("expected %d domains in posts, got %d", len(domains), count)
+	}
+}
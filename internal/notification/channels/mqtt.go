@@ -0,0 +1,87 @@
+package channels
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+)
+
+const mqttTimeout = 10 * time.Second
+
+type MQTTChannel struct {
+	brokerURL   string
+	clientID    string
+	username    string
+	password    string
+	topicPrefix string
+	qos         byte
+	retain      bool
+}
+
+// NewMQTTChannel creates a channel that publishes events to <topicPrefix>/<group>/<domain>.
+// brokerURL is e.g. tcp://localhost:1883, ssl://broker:8883 or ws://broker:80/mqtt.
+func NewMQTTChannel(brokerURL, clientID, username, password, topicPrefix string, qos byte, retain bool) *MQTTChannel {
+	return &MQTTChannel{
+		brokerURL:   brokerURL,
+		clientID:    clientID,
+		username:    username,
+		password:    password,
+		topicPrefix: strings.TrimSuffix(topicPrefix, "/"),
+		qos:         qos,
+		retain:      retain,
+	}
+}
+
+// Publish sends every event as a JSON message. With retain enabled, new subscribers
+// receive the latest state of every domain.
+func (m *MQTTChannel) Publish(events []DomainEvent) error {
+	options := mqtt.NewClientOptions().
+		AddBroker(m.brokerURL).
+		SetClientID(m.clientID).
+		SetUsername(m.username).
+		SetPassword(m.password).
+		SetConnectTimeout(mqttTimeout).
+		SetAutoReconnect(false)
+
+	client := mqtt.NewClient(options)
+	if err := mqttWait(client.Connect()); err != nil {
+		return fmt.Errorf("mqtt: connect failed: %w", err)
+	}
+	defer client.Disconnect(250)
+
+	for _, event := range events {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("mqtt: marshal failed: %w", err)
+		}
+
+		if err := mqttWait(client.Publish(m.topic(event), m.qos, m.retain, payload)); err != nil {
+			return fmt.Errorf("mqtt: publish failed: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (m *MQTTChannel) topic(event DomainEvent) string {
+	group := event.Group
+	if group == "" {
+		group = "ungrouped"
+	}
+	return m.topicPrefix + "/" + mqttTopicLevel(group) + "/" + mqttTopicLevel(event.Domain)
+}
+
+// mqttTopicLevel replaces the level separator and wildcards, which are not allowed in a topic level.
+func mqttTopicLevel(s string) string {
+	return strings.NewReplacer("/", "_", "+", "_", "#", "_").Replace(s)
+}
+
+func mqttWait(token mqtt.Token) error {
+	if !token.WaitTimeout(mqttTimeout) {
+		return fmt.Errorf("timeout after %s", mqttTimeout)
+	}
+	return token.Error()
+}
@@ -0,0 +1,68 @@
+package channels
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+)
+
+func TestMQTTChannel_Topic(t *testing.T) {
+	channel := NewMQTTChannel("tcp://localhost:1883", "test", "", "", "kzdm/", 1, true)
+
+	tests := []struct {
+		event DomainEvent
+		want  string
+	}{
+		{DomainEvent{Domain: "example.kz", Group: "Сайты"}, "kzdm/Сайты/example.kz"},
+		{DomainEvent{Domain: "example.kz"}, "kzdm/ungrouped/example.kz"},
+		{DomainEvent{Domain: "example.kz", Group: "A/B #1"}, "kzdm/A_B _1/example.kz"},
+	}
+
+	for _, tt := range tests {
+		if got := channel.topic(tt.event); got != tt.want {
+			t.Errorf("topic(%+v) = %s, want %s", tt.event, got, tt.want)
+		}
+	}
+}
+
+// TestMQTTChannel_Publish runs against a local broker, e.g.
+// docker run --rm -p 1883:1883 eclipse-mosquitto:2 mosquitto -c /mosquitto-no-auth.conf
+func TestMQTTChannel_Publish(t *testing.T) {
+	brokerURL := os.Getenv("KZDM_TEST_MQTT_URL")
+	if brokerURL == "" {
+		t.Skip("KZDM_TEST_MQTT_URL is not set")
+	}
+
+	received := make(chan DomainEvent, 1)
+	subscriber := mqtt.NewClient(mqtt.NewClientOptions().AddBroker(brokerURL).SetClientID("kzdm-test-subscriber"))
+	if err := mqttWait(subscriber.Connect()); err != nil {
+		t.Fatal(err)
+	}
+	defer subscriber.Disconnect(250)
+
+	token := subscriber.Subscribe("kzdm-test/#", 1, func(_ mqtt.Client, message mqtt.Message) {
+		var event DomainEvent
+		json.Unmarshal(message.Payload(), &event)
+		received <- event
+	})
+	if err := mqttWait(token); err != nil {
+		t.Fatal(err)
+	}
+
+	channel := NewMQTTChannel(brokerURL, "kzdm-test-publisher", "", "", "kzdm-test", 1, false)
+	if err := channel.Publish([]DomainEvent{{Domain: "example.kz", Severity: "warning"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case event := <-received:
+		if event.Domain != "example.kz" || event.Severity != "warning" {
+			t.Errorf("unexpected event: %+v", event)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("event was not received")
+	}
+}
@@ -0,0 +1,62 @@
+package channels
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+type NATSChannel struct {
+	url           string
+	credsFile     string
+	subjectPrefix string
+}
+
+// NewNATSChannel creates a channel that publishes events to <subjectPrefix>.<severity>.<domain>,
+// so subscribers can filter with wildcards, e.g. kzdm.critical.>.
+func NewNATSChannel(url, credsFile, subjectPrefix string) *NATSChannel {
+	return &NATSChannel{url: url, credsFile: credsFile, subjectPrefix: strings.TrimSuffix(subjectPrefix, ".")}
+}
+
+// Publish sends every event as a JSON message and waits until the server has processed them.
+func (n *NATSChannel) Publish(events []DomainEvent) error {
+	options := []nats.Option{nats.Name("kz-domain-monitor"), nats.Timeout(10 * time.Second)}
+	if n.credsFile != "" {
+		options = append(options, nats.UserCredentials(n.credsFile))
+	}
+
+	conn, err := nats.Connect(n.url, options...)
+	if err != nil {
+		return fmt.Errorf("nats: connect failed: %w", err)
+	}
+	defer conn.Close()
+
+	for _, event := range events {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("nats: marshal failed: %w", err)
+		}
+
+		if err := conn.Publish(n.subject(event), payload); err != nil {
+			return fmt.Errorf("nats: publish failed: %w", err)
+		}
+	}
+
+	if err := conn.FlushTimeout(10 * time.Second); err != nil {
+		return fmt.Errorf("nats: flush failed: %w", err)
+	}
+
+	return nil
+}
+
+func (n *NATSChannel) subject(event DomainEvent) string {
+	return n.subjectPrefix + "." + event.Severity + "." + natsToken(event.Domain)
+}
+
+// natsToken replaces whitespace and wildcards, which are not allowed in subjects.
+func natsToken(s string) string {
+	return strings.NewReplacer(" ", "_", "\t", "_", "*", "_", ">", "_").Replace(s)
+}
@@ -0,0 +1,56 @@
+package channels
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+func TestNATSChannel_Subject(t *testing.T) {
+	channel := NewNATSChannel("nats://localhost:4222", "", "kzdm.")
+
+	if got := channel.subject(DomainEvent{Domain: "example.kz", Severity: "critical"}); got != "kzdm.critical.example.kz" {
+		t.Errorf("unexpected subject: %s", got)
+	}
+}
+
+// TestNATSChannel_Publish runs against a local server, e.g. docker run --rm -p 4222:4222 nats:2
+func TestNATSChannel_Publish(t *testing.T) {
+	url := os.Getenv("KZDM_TEST_NATS_URL")
+	if url == "" {
+		t.Skip("KZDM_TEST_NATS_URL is not set")
+	}
+
+	conn, err := nats.Connect(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	subscription, err := conn.SubscribeSync("kzdm-test.warning.>")
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn.Flush()
+
+	channel := NewNATSChannel(url, "", "kzdm-test")
+	if err := channel.Publish([]DomainEvent{{Domain: "example.kz", Severity: "warning"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	message, err := subscription.NextMsg(5 * time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var event DomainEvent
+	if err := json.Unmarshal(message.Data, &event); err != nil {
+		t.Fatal(err)
+	}
+	if message.Subject != "kzdm-test.warning.example.kz" || event.Domain != "example.kz" {
+		t.Errorf("unexpected message %s: %+v", message.Subject, event)
+	}
+}
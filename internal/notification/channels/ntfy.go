@@ -0,0 +1,81 @@
+package channels
+
+import (
+	"github.com/Kravets1996/kz-domain-monitor/internal/api"
+	"github.com/Kravets1996/kz-domain-monitor/internal/report"
+	"mime"
+	"net/http"
+)
+
+// ntfyMessageLimit is the largest message ntfy delivers as text instead of converting it into an attachment.
+const ntfyMessageLimit = 4096
+
+type NtfyChannel struct {
+	topicURL string
+	token    string
+}
+
+// NewNtfyChannel creates a channel that publishes to an ntfy topic URL, e.g. https://ntfy.sh/my-domains.
+// The token is optional and only needed for protected topics.
+func NewNtfyChannel(topicURL, token string) *NtfyChannel {
+	return &NtfyChannel{topicURL: topicURL, token: token}
+}
+
+// Send publishes the report with priority and tags mapped from the worst severity.
+// Silent messages use the minimal priority, which does not produce a sound or vibration.
+func (n *NtfyChannel) Send(header string, rep report.Report, silent bool) error {
+	severity := rep.Severity()
+
+	title, blocks, err := titledBlocks(header, rep)
+	if err != nil {
+		return err
+	}
+
+	// Non-ASCII header values are RFC 2047 encoded, which ntfy decodes.
+	headers := map[string]string{
+		"X-Title":    mime.BEncoding.Encode("UTF-8", title),
+		"X-Priority": ntfyPriority(severity, silent),
+		"X-Tags":     ntfyTag(severity),
+	}
+	if n.token != "" {
+		headers["Authorization"] = "Bearer " + n.token
+	}
+
+	for _, message := range splitBlocks(blocks, ntfyMessageLimit) {
+		_, err := sendRequest("ntfy", http.MethodPost, n.topicURL, "text/plain; charset=utf-8", []byte(message), headers)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func ntfyPriority(severity api.Severity, silent bool) string {
+	if silent {
+		return "min"
+	}
+
+	switch severity {
+	case api.SeverityCritical:
+		return "urgent"
+	case api.SeverityError:
+		return "high"
+	case api.SeverityWarning:
+		return "default"
+	default:
+		return "low"
+	}
+}
+
+func ntfyTag(severity api.Severity) string {
+	switch severity {
+	case api.SeverityCritical:
+		return "rotating_light"
+	case api.SeverityError:
+		return "x"
+	case api.SeverityWarning:
+		return "warning"
+	default:
+		return "white_check_mark"
+	}
+}
@@ -0,0 +1,65 @@
+package channels
+
+import (
+	"github.com/Kravets1996/kz-domain-monitor/internal/api"
+	"github.com/Kravets1996/kz-domain-monitor/internal/report"
+	"io"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNtfyChannel_Send(t *testing.T) {
+	var (
+		headers http.Header
+		body    string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = r.Header
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+	}))
+	defer server.Close()
+
+	expired := time.Now().AddDate(0, 0, -5)
+	rep := report.Report{Groups: []report.Group{{Domains: []api.Domain{{Name: "expired.kz", ExpirationDate: &expired, Policy: testPolicy}}}}}
+
+	if err := NewNtfyChannel(server.URL+"/domains", "tk_token").Send("Header ", rep, false); err != nil {
+		t.Fatal(err)
+	}
+
+	if headers.Get("X-Title") != "Header" || headers.Get("X-Priority") != "urgent" || headers.Get("X-Tags") != "rotating_light" {
+		t.Errorf("unexpected headers: %v", headers)
+	}
+	if headers.Get("Authorization") != "Bearer tk_token" {
+		t.Errorf("unexpected authorization: %s", headers.Get("Authorization"))
+	}
+	if body != "❗️ истёк 5 дней назад - expired.kz" {
+		t.Errorf("unexpected body: %q", body)
+	}
+}
+
+func TestNtfyChannel_Send_EncodesTitle(t *testing.T) {
+	var title string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		title = r.Header.Get("X-Title")
+	}))
+	defer server.Close()
+
+	if err := NewNtfyChannel(server.URL, "").Send("Доменам осталось", getChatReport(), true); err != nil {
+		t.Fatal(err)
+	}
+
+	decoded, err := new(mime.WordDecoder).DecodeHeader(title)
+	if err != nil || title == decoded || decoded != "Доменам осталось" {
+		t.Errorf("title should be RFC 2047 encoded, got %q", title)
+	}
+}
+
+func TestNtfyPriority_Silent(t *testing.T) {
+	if priority := ntfyPriority(api.SeverityOk, true); priority != "min" {
+		t.Errorf("silent messages should use min priority, got %s", priority)
+	}
+}
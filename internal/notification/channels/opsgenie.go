@@ -0,0 +1,140 @@
+package channels
+
+import (
+	"github.com/Kravets1996/kz-domain-monitor/internal/api"
+	"github.com/Kravets1996/kz-domain-monitor/internal/i18n"
+	"github.com/Kravets1996/kz-domain-monitor/internal/state"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	opsgenieIntegration = "opsgenie"
+	// opsgenieMessageLimit and opsgenieTagLimit are the field limits of the Alert API.
+	opsgenieMessageLimit = 130
+	opsgenieTagLimit     = 50
+)
+
+type OpsgenieChannel struct {
+	apiURL  string
+	apiKey  string
+	printer i18n.Printer
+}
+
+type opsgenieAlert struct {
+	Message     string            `json:"message"`
+	Alias       string            `json:"alias"`
+	Description string            `json:"description"`
+	Tags        []string          `json:"tags"`
+	Entity      string            `json:"entity"`
+	Source      string            `json:"source"`
+	Priority    string            `json:"priority"`
+	Details     map[string]string `json:"details"`
+}
+
+type opsgenieClose struct {
+	Source string `json:"source"`
+	Note   string `json:"note"`
+}
+
+// NewOpsgenieChannel creates a channel for the Opsgenie Alert API, e.g. https://api.opsgenie.com
+// or https://api.eu.opsgenie.com, authenticated with an API integration key. Alerts are written in lang.
+func NewOpsgenieChannel(apiURL, apiKey, lang string) *OpsgenieChannel {
+	return &OpsgenieChannel{apiURL: strings.TrimSuffix(apiURL, "/"), apiKey: apiKey, printer: i18n.New(lang)}
+}
+
+// Sync creates an alert for every expiring, expired or available domain and closes the alerts
+// of domains that are ok again or no longer monitored. Domains whose check failed keep their alert.
+// groupTitle returns the group of a domain, used as an alert tag.
+func (o *OpsgenieChannel) Sync(domains []api.Domain, groupTitle func(domain string) string, alerts *state.State) error {
+	checked := make(map[string]api.Domain, len(domains))
+
+	for _, domain := range domains {
+		checked[domain.Name] = domain
+
+		severity := domain.GetSeverity()
+		if severity != api.SeverityWarning && severity != api.SeverityCritical {
+			continue
+		}
+
+		tags := []string{"kz-domain-monitor"}
+		if title := groupTitle(domain.Name); title != "" {
+			tags = append(tags, truncate(title, opsgenieTagLimit))
+		}
+
+		priority := opsgeniePriority(domain)
+
+		_, err := postJSON("opsgenie", o.apiURL+"/v2/alerts", opsgenieAlert{
+			Message:     truncate(domain.Message(o.printer), opsgenieMessageLimit),
+			Alias:       opsgenieAlias(domain.Name),
+			Description: domainStatus(o.printer, domain),
+			Tags:        tags,
+			Entity:      domain.Name,
+			Source:      "kz-domain-monitor",
+			Priority:    priority,
+			Details:     opsgenieDetails(domain),
+		}, o.headers())
+		if err != nil {
+			return err
+		}
+
+		alert := state.Alert{Severity: priority, UpdatedAt: time.Now()}
+		if domain.ExpirationDate != nil {
+			alert.DaysLeft = domain.GetDaysToExpire()
+		}
+		alerts.Set(opsgenieIntegration, domain.Name, alert)
+	}
+
+	for _, name := range alerts.Domains(opsgenieIntegration) {
+		if domain, ok := checked[name]; ok && domain.GetSeverity() != api.SeverityOk {
+			continue
+		}
+
+		closeURL := o.apiURL + "/v2/alerts/" + url.PathEscape(opsgenieAlias(name)) + "/close?identifierType=alias"
+		_, err := postJSON("opsgenie", closeURL, opsgenieClose{
+			Source: "kz-domain-monitor",
+			Note:   o.printer.T("alert.closed"),
+		}, o.headers())
+		if err != nil {
+			return err
+		}
+
+		alerts.Delete(opsgenieIntegration, name)
+	}
+
+	return nil
+}
+
+func (o *OpsgenieChannel) headers() map[string]string {
+	return map[string]string{"Authorization": "GenieKey " + o.apiKey}
+}
+
+func opsgenieAlias(domain string) string {
+	return "kz-domain-monitor/" + domain
+}
+
+// opsgeniePriority maps days left to a priority: P1 for expired or available domains,
+// P2 for up to 3 days, P3 for up to 7 days and P4 otherwise.
+func opsgeniePriority(domain api.Domain) string {
+	if domain.GetSeverity() == api.SeverityCritical {
+		return "P1"
+	}
+
+	switch days := domain.GetDaysToExpire(); {
+	case days <= 3:
+		return "P2"
+	case days <= 7:
+		return "P3"
+	default:
+		return "P4"
+	}
+}
+
+func opsgenieDetails(domain api.Domain) map[string]string {
+	details := map[string]string{"domain": domain.Name}
+	if domain.ExpirationDate != nil {
+		details["expiration_date"] = domain.ExpiresAt().Format(time.RFC3339)
+	}
+	return details
+}
@@ -0,0 +1,91 @@
+package channels
+
+import (
+	"encoding/json"
+	"github.com/Kravets1996/kz-domain-monitor/internal/api"
+	"github.com/Kravets1996/kz-domain-monitor/internal/state"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestOpsgenieChannel_Sync(t *testing.T) {
+	var (
+		paths  []string
+		alerts []opsgenieAlert
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "GenieKey api-key" {
+			t.Errorf("unexpected authorization header: %q", r.Header.Get("Authorization"))
+		}
+		paths = append(paths, r.URL.RequestURI())
+		if r.URL.Path == "/v2/alerts" {
+			var alert opsgenieAlert
+			if err := json.NewDecoder(r.Body).Decode(&alert); err != nil {
+				t.Fatal(err)
+			}
+			alerts = append(alerts, alert)
+		}
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"result": "Request will be processed"}`))
+	}))
+	defer server.Close()
+
+	alertState, err := state.Load(filepath.Join(t.TempDir(), "state.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	channel := NewOpsgenieChannel(server.URL+"/", "api-key", "ru")
+	groupTitle := func(string) string { return "Основные" }
+
+	expiring := time.Now().AddDate(0, 0, 2)
+	renewed := time.Now().AddDate(0, 0, 365)
+
+	if err := channel.Sync([]api.Domain{{Name: "example.kz", ExpirationDate: &expiring, Policy: testPolicy}}, groupTitle, alertState); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(alerts) != 1 || alerts[0].Alias != "kz-domain-monitor/example.kz" || alerts[0].Priority != "P2" {
+		t.Fatalf("expected P2 alert, got %+v", alerts)
+	}
+	if len(alerts[0].Tags) != 2 || alerts[0].Tags[1] != "Основные" {
+		t.Errorf("expected group tag, got %v", alerts[0].Tags)
+	}
+
+	paths = nil
+	if err := channel.Sync([]api.Domain{{Name: "example.kz", ExpirationDate: &renewed, Policy: testPolicy}}, groupTitle, alertState); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(paths) != 1 || paths[0] != "/v2/alerts/kz-domain-monitor%2Fexample.kz/close?identifierType=alias" {
+		t.Fatalf("expected close request, got %v", paths)
+	}
+	if len(alertState.Domains(opsgenieIntegration)) != 0 {
+		t.Error("closed domain should be removed from state")
+	}
+}
+
+func TestOpsgeniePriority(t *testing.T) {
+	expired := time.Now().AddDate(0, 0, -1)
+	week := time.Now().AddDate(0, 0, 6)
+	month := time.Now().AddDate(0, 0, 12)
+
+	tests := []struct {
+		domain api.Domain
+		want   string
+	}{
+		{api.Domain{Name: "expired.kz", ExpirationDate: &expired, Policy: testPolicy}, "P1"},
+		{api.Domain{Name: "free.kz", IsAvailable: true}, "P1"},
+		{api.Domain{Name: "week.kz", ExpirationDate: &week, Policy: testPolicy}, "P3"},
+		{api.Domain{Name: "month.kz", ExpirationDate: &month, Policy: testPolicy}, "P4"},
+	}
+
+	for _, tt := range tests {
+		if got := opsgeniePriority(tt.domain); got != tt.want {
+			t.Errorf("opsgeniePriority(%s) = %s, want %s", tt.domain.Name, got, tt.want)
+		}
+	}
+}
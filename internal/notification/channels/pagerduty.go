@@ -0,0 +1,112 @@
+package channels
+
+import (
+	"github.com/Kravets1996/kz-domain-monitor/internal/api"
+	"github.com/Kravets1996/kz-domain-monitor/internal/state"
+	"time"
+)
+
+const pagerDutyIntegration = "pagerduty"
+
+type PagerDutyChannel struct {
+	eventsURL  string
+	routingKey string
+}
+
+type pagerDutyEvent struct {
+	RoutingKey  string            `json:"routing_key"`
+	EventAction string            `json:"event_action"`
+	DedupKey    string            `json:"dedup_key"`
+	Payload     *pagerDutyPayload `json:"payload,omitempty"`
+}
+
+type pagerDutyPayload struct {
+	Summary       string         `json:"summary"`
+	Source        string         `json:"source"`
+	Severity      string         `json:"severity"`
+	Component     string         `json:"component"`
+	CustomDetails map[string]any `json:"custom_details"`
+}
+
+// NewPagerDutyChannel creates a channel that sends Events API v2 events with an integration routing key.
+func NewPagerDutyChannel(eventsURL, routingKey string) *PagerDutyChannel {
+	return &PagerDutyChannel{eventsURL: eventsURL, routingKey: routingKey}
+}
+
+// Sync triggers an incident for every expiring, expired or available domain and resolves the incidents
+// of domains that are ok again or no longer monitored. Domains whose check failed keep their incident state.
+func (p *PagerDutyChannel) Sync(domains []api.Domain, alerts *state.State) error {
+	checked := make(map[string]api.Domain, len(domains))
+
+	for _, domain := range domains {
+		checked[domain.Name] = domain
+
+		severity := domain.GetSeverity()
+		if severity != api.SeverityWarning && severity != api.SeverityCritical {
+			continue
+		}
+
+		err := p.send(pagerDutyEvent{
+			RoutingKey:  p.routingKey,
+			EventAction: "trigger",
+			DedupKey:    pagerDutyDedupKey(domain.Name),
+			Payload: &pagerDutyPayload{
+				Summary:       domain.GetMessage(),
+				Source:        "kz-domain-monitor",
+				Severity:      severity.String(),
+				Component:     domain.Name,
+				CustomDetails: pagerDutyDetails(domain),
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		alert := state.Alert{Severity: severity.String(), UpdatedAt: time.Now()}
+		if domain.ExpirationDate != nil {
+			alert.DaysLeft = domain.GetDaysToExpire()
+		}
+		alerts.Set(pagerDutyIntegration, domain.Name, alert)
+	}
+
+	for _, name := range alerts.Domains(pagerDutyIntegration) {
+		if domain, ok := checked[name]; ok && domain.GetSeverity() != api.SeverityOk {
+			continue
+		}
+
+		err := p.send(pagerDutyEvent{
+			RoutingKey:  p.routingKey,
+			EventAction: "resolve",
+			DedupKey:    pagerDutyDedupKey(name),
+		})
+		if err != nil {
+			return err
+		}
+
+		alerts.Delete(pagerDutyIntegration, name)
+	}
+
+	return nil
+}
+
+func (p *PagerDutyChannel) send(event pagerDutyEvent) error {
+	_, err := postJSON("pagerduty", p.eventsURL, event, nil)
+	return err
+}
+
+// pagerDutyDedupKey returns a stable key so repeated triggers update the same incident.
+func pagerDutyDedupKey(domain string) string {
+	return "kz-domain-monitor/" + domain
+}
+
+func pagerDutyDetails(domain api.Domain) map[string]any {
+	details := map[string]any{
+		"domain":    domain.Name,
+		"available": domain.IsAvailable,
+	}
+	if domain.ExpirationDate != nil {
+		details["days_left"] = domain.GetDaysToExpire()
+		details["expiration_date"] = domain.ExpiresAt().Format(time.RFC3339)
+	}
+	return details
+}
@@ -0,0 +1,68 @@
+package channels
+
+import (
+	"encoding/json"
+	"github.com/Kravets1996/kz-domain-monitor/internal/api"
+	"github.com/Kravets1996/kz-domain-monitor/internal/state"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestPagerDutyChannel_Sync(t *testing.T) {
+	var events []pagerDutyEvent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event pagerDutyEvent
+		if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+			t.Fatal(err)
+		}
+		events = append(events, event)
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"status": "success"}`))
+	}))
+	defer server.Close()
+
+	alerts, err := state.Load(filepath.Join(t.TempDir(), "state.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	channel := NewPagerDutyChannel(server.URL, "routing-key")
+
+	expiring := time.Now().AddDate(0, 0, 3)
+	renewed := time.Now().AddDate(0, 0, 365)
+
+	if err := channel.Sync([]api.Domain{{Name: "example.kz", ExpirationDate: &expiring, Policy: testPolicy}}, alerts); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(events) != 1 || events[0].EventAction != "trigger" || events[0].DedupKey != "kz-domain-monitor/example.kz" {
+		t.Fatalf("expected trigger event, got %+v", events)
+	}
+	if events[0].Payload.Severity != "warning" || events[0].RoutingKey != "routing-key" {
+		t.Errorf("unexpected payload: %+v", events[0].Payload)
+	}
+
+	// A failed check keeps the incident open.
+	events = nil
+	if err := channel.Sync([]api.Domain{{Name: "example.kz", Error: http.ErrHandlerTimeout}}, alerts); err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 0 {
+		t.Errorf("failed check should not change the incident: %+v", events)
+	}
+
+	events = nil
+	if err := channel.Sync([]api.Domain{{Name: "example.kz", ExpirationDate: &renewed, Policy: testPolicy}}, alerts); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(events) != 1 || events[0].EventAction != "resolve" || events[0].DedupKey != "kz-domain-monitor/example.kz" {
+		t.Fatalf("expected resolve event, got %+v", events)
+	}
+	if len(alerts.Domains(pagerDutyIntegration)) != 0 {
+		t.Error("resolved domain should be removed from state")
+	}
+}
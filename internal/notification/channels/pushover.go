@@ -0,0 +1,90 @@
+package channels
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/Kravets1996/kz-domain-monitor/internal/api"
+	"github.com/Kravets1996/kz-domain-monitor/internal/report"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	// pushoverMessageLimit is the maximum message length accepted by Pushover.
+	pushoverMessageLimit = 1024
+	// Emergency notifications are repeated every pushoverRetry seconds until acknowledged or pushoverExpire passes.
+	pushoverRetry  = 300
+	pushoverExpire = 3600
+)
+
+type PushoverChannel struct {
+	apiURL   string
+	appToken string
+	userKey  string
+}
+
+type pushoverResponse struct {
+	Status int      `json:"status"`
+	Errors []string `json:"errors"`
+}
+
+func NewPushoverChannel(appToken, userKey string) *PushoverChannel {
+	return &PushoverChannel{
+		apiURL:   "https://api.pushover.net/1/messages.json",
+		appToken: appToken,
+		userKey:  userKey,
+	}
+}
+
+// Send delivers the report, split into several messages if needed.
+// Expired or available domains are sent with emergency priority, silent reports with low priority.
+func (p *PushoverChannel) Send(header string, rep report.Report, silent bool) error {
+	priority := pushoverPriority(rep.Severity(), silent)
+
+	title, blocks, err := titledBlocks(header, rep)
+	if err != nil {
+		return err
+	}
+
+	for _, message := range splitBlocks(blocks, pushoverMessageLimit) {
+		data := url.Values{}
+		data.Set("token", p.appToken)
+		data.Set("user", p.userKey)
+		data.Set("title", title)
+		data.Set("message", message)
+		data.Set("priority", strconv.Itoa(priority))
+		if priority == 2 {
+			data.Set("retry", strconv.Itoa(pushoverRetry))
+			data.Set("expire", strconv.Itoa(pushoverExpire))
+		}
+
+		body, err := sendRequest("pushover", http.MethodPost, p.apiURL, "application/x-www-form-urlencoded", []byte(data.Encode()), nil)
+		if err != nil {
+			return err
+		}
+
+		var response pushoverResponse
+		if err := json.Unmarshal(body, &response); err != nil {
+			return fmt.Errorf("pushover: failed to parse response: %w", err)
+		}
+		if response.Status != 1 {
+			return fmt.Errorf("pushover: %s", strings.Join(response.Errors, ", "))
+		}
+	}
+	return nil
+}
+
+func pushoverPriority(severity api.Severity, silent bool) int {
+	switch {
+	case silent:
+		return -1
+	case severity == api.SeverityCritical:
+		return 2
+	case severity == api.SeverityOk:
+		return -1
+	default:
+		return 0
+	}
+}
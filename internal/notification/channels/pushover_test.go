@@ -0,0 +1,67 @@
+package channels
+
+import (
+	"fmt"
+	"github.com/Kravets1996/kz-domain-monitor/internal/api"
+	"github.com/Kravets1996/kz-domain-monitor/internal/report"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestPushoverChannel_Send_Emergency(t *testing.T) {
+	var requests []url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatal(err)
+		}
+		requests = append(requests, r.PostForm)
+		w.Write([]byte(`{"status": 1, "request": "id"}`))
+	}))
+	defer server.Close()
+
+	expiration := time.Now().AddDate(0, 0, 90)
+	expired := time.Now().AddDate(0, 0, -5)
+
+	domains := []api.Domain{{Name: "expired.kz", ExpirationDate: &expired, Policy: testPolicy}}
+	for i := 0; i < 100; i++ {
+		domains = append(domains, api.Domain{Name: fmt.Sprintf("example-%d.kz", i), ExpirationDate: &expiration, Policy: testPolicy})
+	}
+
+	channel := NewPushoverChannel("app-token", "user-key")
+	channel.apiURL = server.URL
+
+	if err := channel.Send("Header", report.Report{Groups: []report.Group{{Domains: domains}}}, false); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(requests) < 2 {
+		t.Fatalf("expected report to be split, got %d messages", len(requests))
+	}
+	for _, request := range requests {
+		if request.Get("priority") != "2" || request.Get("retry") == "" || request.Get("expire") == "" {
+			t.Errorf("expected emergency priority with retry and expire: %v", request)
+		}
+		if utf8.RuneCountInString(request.Get("message")) > pushoverMessageLimit {
+			t.Errorf("message exceeds limit: %d", utf8.RuneCountInString(request.Get("message")))
+		}
+	}
+}
+
+func TestPushoverChannel_Send_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"status": 0, "errors": ["user identifier is invalid"]}`))
+	}))
+	defer server.Close()
+
+	channel := NewPushoverChannel("app-token", "user-key")
+	channel.apiURL = server.URL
+
+	if err := channel.Send("Header", getChatReport(), true); err == nil {
+		t.Error("expected error for invalid user key")
+	}
+}
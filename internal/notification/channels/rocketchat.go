@@ -0,0 +1,86 @@
+package channels
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/Kravets1996/kz-domain-monitor/internal/report"
+	"strings"
+)
+
+// rocketChatMessageLimit is the default maximum message size of Rocket.Chat (Message_MaxAllowedSize).
+const rocketChatMessageLimit = 5000
+
+type RocketChatChannel struct {
+	webhookURL string
+	channel    string
+	alias      string
+	avatarURL  string
+}
+
+type rocketChatMessage struct {
+	Text        string           `json:"text"`
+	Channel     string           `json:"channel,omitempty"`
+	Alias       string           `json:"alias,omitempty"`
+	Avatar      string           `json:"avatar,omitempty"`
+	Attachments []chatAttachment `json:"attachments,omitempty"`
+}
+
+type rocketChatResponse struct {
+	Success bool   `json:"success"`
+	Error   string `json:"error"`
+}
+
+// NewRocketChatChannel creates a channel for a Rocket.Chat incoming webhook integration.
+// Empty channel, alias and avatarURL keep the integration defaults.
+func NewRocketChatChannel(webhookURL, channel, alias, avatarURL string) *RocketChatChannel {
+	return &RocketChatChannel{
+		webhookURL: webhookURL,
+		channel:    channel,
+		alias:      alias,
+		avatarURL:  avatarURL,
+	}
+}
+
+// Send posts the report header as text and a colored attachment per group, split into several messages
+// to respect the Rocket.Chat message size. Rocket.Chat ignores the fallback of attachments, so it is dropped
+// from the payload.
+func (r *RocketChatChannel) Send(header string, rep report.Report) error {
+	attachments := chatAttachments(rep, rocketChatMessageLimit)
+	for i := range attachments {
+		attachments[i].Fallback = ""
+	}
+
+	text := chatText("*"+strings.TrimSpace(header)+"*", rep)
+	for i, part := range packChatAttachments(text, attachments, rocketChatMessageLimit) {
+		message := rocketChatMessage{
+			Channel:     r.channel,
+			Alias:       r.alias,
+			Avatar:      r.avatarURL,
+			Attachments: part,
+		}
+		if i == 0 {
+			message.Text = text
+		}
+
+		if err := r.post(message); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *RocketChatChannel) post(message rocketChatMessage) error {
+	body, err := postJSON("rocketchat", r.webhookURL, message, nil)
+	if err != nil {
+		return err
+	}
+
+	// Rocket.Chat answers 200 with {"success": false} when the integration script rejects the message.
+	var response rocketChatResponse
+	if json.Unmarshal(body, &response) == nil && !response.Success && response.Error != "" {
+		return fmt.Errorf("rocketchat: %s", response.Error)
+	}
+
+	return nil
+}
@@ -0,0 +1,20 @@
+package channels
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRocketChatChannel_Send_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"success": false, "error": "Invalid channel"}`))
+	}))
+	defer server.Close()
+
+	err := NewRocketChatChannel(server.URL, "#unknown", "", "").Send("Header", getChatReport())
+
+	if err == nil || err.Error() != "rocketchat: Invalid channel" {
+		t.Errorf("expected rocketchat error, got %v", err)
+	}
+}
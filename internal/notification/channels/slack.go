@@ -1,52 +1,233 @@
 package channels
 
 import (
-	"bytes"
-	"context"
 	"encoding/json"
 	"fmt"
-	"io"
+	"github.com/Kravets1996/kz-domain-monitor/internal/api"
+	"github.com/Kravets1996/kz-domain-monitor/internal/i18n"
+	"github.com/Kravets1996/kz-domain-monitor/internal/report"
 	"net/http"
-	"time"
+	"strings"
+)
+
+const (
+	// slackBlockLimit is the maximum number of blocks in a single Slack message, attachments included.
+	slackBlockLimit = 50
+	// slackFieldLimit is the maximum number of fields in a section block.
+	slackFieldLimit = 10
 )
 
 type SlackChannel struct {
 	webhookURL string
+	apiURL     string
+	botToken   string
+	channelID  string
+	thread     bool
 }
 
+type slackMessage struct {
+	Channel     string            `json:"channel,omitempty"`
+	ThreadTS    string            `json:"thread_ts,omitempty"`
+	Text        string            `json:"text"`
+	Blocks      []slackBlock      `json:"blocks,omitempty"`
+	Attachments []slackAttachment `json:"attachments,omitempty"`
+}
+
+type slackBlock struct {
+	Type     string      `json:"type"`
+	Text     *slackText  `json:"text,omitempty"`
+	Fields   []slackText `json:"fields,omitempty"`
+	Elements []slackText `json:"elements,omitempty"`
+}
+
+type slackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type slackAttachment struct {
+	Color  string       `json:"color"`
+	Blocks []slackBlock `json:"blocks"`
+}
+
+type slackAPIResponse struct {
+	OK    bool   `json:"ok"`
+	Error string `json:"error"`
+	TS    string `json:"ts"`
+}
+
+// NewSlackChannel creates a channel that posts to an incoming webhook.
 func NewSlackChannel(webhookURL string) *SlackChannel {
 	return &SlackChannel{webhookURL: webhookURL}
 }
 
-func (s *SlackChannel) Send(message string) error {
-	payload, err := json.Marshal(map[string]string{"text": message})
-	if err != nil {
-		return fmt.Errorf("slack: marshal failed: %w", err)
+// NewSlackBotChannel creates a channel that posts to channelID with chat.postMessage.
+// With thread enabled the groups are posted as replies to the header message.
+func NewSlackBotChannel(botToken, channelID string, thread bool) *SlackChannel {
+	return &SlackChannel{
+		apiURL:    "https://slack.com/api",
+		botToken:  botToken,
+		channelID: channelID,
+		thread:    thread,
+	}
+}
+
+// Send renders the report with Block Kit: a header with a summary and a colored attachment per group.
+func (s *SlackChannel) Send(header string, rep report.Report) error {
+	headerBlocks := slackHeaderBlocks(header, rep)
+	attachments := slackGroupAttachments(rep)
+
+	if s.thread && s.botToken != "" {
+		threadTS, err := s.post(slackMessage{Text: header, Blocks: headerBlocks})
+		if err != nil {
+			return err
+		}
+
+		for _, message := range packSlackMessages(nil, attachments) {
+			message.Text = header
+			message.ThreadTS = threadTS
+			if _, err := s.post(message); err != nil {
+				return err
+			}
+		}
+		return nil
 	}
 
-	client := &http.Client{
-		Timeout: 10 * time.Second,
+	for _, message := range packSlackMessages(headerBlocks, attachments) {
+		message.Text = header
+		if _, err := s.post(message); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// post sends a message and returns its timestamp (only available in bot mode).
+func (s *SlackChannel) post(message slackMessage) (string, error) {
+	url := s.webhookURL
+	if s.botToken != "" {
+		url = s.apiURL + "/chat.postMessage"
+		message.Channel = s.channelID
 	}
 
-	ctx := context.Background()
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.webhookURL, bytes.NewReader(payload))
+	payload, err := json.Marshal(message)
 	if err != nil {
-		return fmt.Errorf("slack: request creation failed: %w", err)
+		return "", fmt.Errorf("slack: marshal failed: %w", err)
 	}
-	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := client.Do(req)
+	var headers map[string]string
+	if s.botToken != "" {
+		headers = map[string]string{"Authorization": "Bearer " + s.botToken}
+	}
+
+	body, err := sendRequest("slack", http.MethodPost, url, "application/json; charset=utf-8", payload, headers)
 	if err != nil {
-		return fmt.Errorf("slack: request failed: %w", err)
+		return "", err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return fmt.Errorf("slack: unexpected status: %s (failed to read response body: %w)", resp.Status, err)
+	if s.botToken == "" {
+		return "", nil
+	}
+
+	var apiResponse slackAPIResponse
+	if err := json.Unmarshal(body, &apiResponse); err != nil {
+		return "", fmt.Errorf("slack: failed to parse response: %w", err)
+	}
+	if !apiResponse.OK {
+		return "", fmt.Errorf("slack: api error: %s", apiResponse.Error)
+	}
+	return apiResponse.TS, nil
+}
+
+func slackHeaderBlocks(header string, rep report.Report) []slackBlock {
+	domains := rep.Domains()
+
+	problems := 0
+	for _, domain := range domains {
+		if !domain.IsOk() {
+			problems++
 		}
-		return fmt.Errorf("slack: unexpected status: %s, body: %s", resp.Status, string(body))
 	}
-	return nil
+
+	blocks := []slackBlock{
+		{Type: "header", Text: &slackText{Type: "plain_text", Text: strings.TrimSpace(header)}},
+		{Type: "context", Elements: []slackText{{
+			Type: "mrkdwn",
+			Text: rep.Printer.T("summary", len(domains), problems),
+		}}},
+	}
+	if notices := noticeText(rep); notices != "" {
+		blocks = append(blocks, slackBlock{Type: "section", Text: &slackText{Type: "plain_text", Text: notices}})
+	}
+	return blocks
+}
+
+// slackGroupAttachments renders every group as an attachment colored by its worst severity.
+// Groups that do not fit into a single message are split into several attachments.
+func slackGroupAttachments(rep report.Report) []slackAttachment {
+	maxDomains := (slackBlockLimit - 2) * slackFieldLimit
+
+	var attachments []slackAttachment
+	for _, group := range rep.Groups {
+		for start := 0; start < len(group.Domains); start += maxDomains {
+			part := report.Group{Title: group.Title, Domains: group.Domains[start:min(start+maxDomains, len(group.Domains))]}
+			attachments = append(attachments, slackGroupAttachment(rep.Printer, part))
+		}
+	}
+	return attachments
+}
+
+func slackGroupAttachment(p i18n.Printer, group report.Group) slackAttachment {
+	var blocks []slackBlock
+
+	if group.Title != "" {
+		blocks = append(blocks, slackBlock{Type: "section", Text: &slackText{Type: "mrkdwn", Text: "*" + slackEscape(group.Title) + "*"}})
+	}
+
+	for start := 0; start < len(group.Domains); start += slackFieldLimit {
+		var fields []slackText
+		for _, domain := range group.Domains[start:min(start+slackFieldLimit, len(group.Domains))] {
+			fields = append(fields, slackText{Type: "mrkdwn", Text: slackDomainField(p, domain)})
+		}
+		blocks = append(blocks, slackBlock{Type: "section", Fields: fields})
+	}
+
+	severity := group.Severity()
+	blocks = append(blocks, slackBlock{Type: "context", Elements: []slackText{{Type: "mrkdwn", Text: severityLabel(p, severity)}}})
+
+	return slackAttachment{Color: severityColor(severity), Blocks: blocks}
+}
+
+func slackDomainField(p i18n.Printer, domain api.Domain) string {
+	return "*" + slackEscape(domain.Name) + "*\n" + slackEscape(domainStatus(p, domain))
+}
+
+// packSlackMessages distributes attachments over messages so that none exceeds the block limit.
+// The first message starts with the given blocks.
+func packSlackMessages(first []slackBlock, attachments []slackAttachment) []slackMessage {
+	var messages []slackMessage
+
+	current := slackMessage{Blocks: first}
+	count := len(first)
+
+	for _, attachment := range attachments {
+		if count+len(attachment.Blocks) > slackBlockLimit && count > 0 {
+			messages = append(messages, current)
+			current = slackMessage{}
+			count = 0
+		}
+		current.Attachments = append(current.Attachments, attachment)
+		count += len(attachment.Blocks)
+	}
+
+	if count > 0 {
+		messages = append(messages, current)
+	}
+
+	return messages
+}
+
+// slackEscape escapes the control characters of Slack mrkdwn.
+func slackEscape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
 }
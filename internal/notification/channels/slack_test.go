@@ -0,0 +1,104 @@
+package channels
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/Kravets1996/kz-domain-monitor/internal/api"
+	"github.com/Kravets1996/kz-domain-monitor/internal/report"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSlackChannel_Send_Thread(t *testing.T) {
+	var messages []slackMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/chat.postMessage" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bearer xoxb-token" {
+			t.Errorf("unexpected authorization: %s", r.Header.Get("Authorization"))
+		}
+
+		var message slackMessage
+		if err := json.NewDecoder(r.Body).Decode(&message); err != nil {
+			t.Fatal(err)
+		}
+		messages = append(messages, message)
+
+		fmt.Fprintf(w, `{"ok": true, "ts": "1700000000.%06d"}`, len(messages))
+	}))
+	defer server.Close()
+
+	channel := NewSlackBotChannel("xoxb-token", "C123", true)
+	channel.apiURL = server.URL
+
+	expiration := time.Now().AddDate(0, 0, 90)
+	rep := report.Report{Groups: []report.Group{
+		{Title: "Сайты", Domains: []api.Domain{{Name: "example.kz", ExpirationDate: &expiration, Policy: testPolicy}}},
+		{Title: "Сервисы", Domains: []api.Domain{{Name: "egov.kz", IsAvailable: true, ExpirationDate: &expiration, Policy: testPolicy}}},
+	}}
+
+	if err := channel.Send("Header", rep); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(messages) != 2 {
+		t.Fatalf("expected parent and 1 reply, got %d messages", len(messages))
+	}
+	if messages[0].Channel != "C123" || messages[0].Blocks[0].Type != "header" {
+		t.Errorf("unexpected parent message: %+v", messages[0])
+	}
+	if messages[1].ThreadTS != "1700000000.000001" {
+		t.Errorf("reply should be posted to the thread, got %q", messages[1].ThreadTS)
+	}
+	if len(messages[1].Attachments) != 2 {
+		t.Fatalf("expected 2 group attachments, got %d", len(messages[1].Attachments))
+	}
+	if messages[1].Attachments[1].Color != severityColor(api.SeverityCritical) {
+		t.Errorf("unexpected color: %s", messages[1].Attachments[1].Color)
+	}
+	if field := messages[1].Attachments[0].Blocks[1].Fields[0].Text; field != "*example.kz*\n✅ 90 дней" {
+		t.Errorf("unexpected field: %q", field)
+	}
+}
+
+func TestSlackChannel_Send_BlockLimit(t *testing.T) {
+	var messages []slackMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var message slackMessage
+		if err := json.NewDecoder(r.Body).Decode(&message); err != nil {
+			t.Fatal(err)
+		}
+		messages = append(messages, message)
+	}))
+	defer server.Close()
+
+	expiration := time.Now().AddDate(0, 0, 90)
+	var domains []api.Domain
+	for i := 0; i < 1000; i++ {
+		domains = append(domains, api.Domain{Name: fmt.Sprintf("example-%d.kz", i), ExpirationDate: &expiration, Policy: testPolicy})
+	}
+
+	if err := NewSlackChannel(server.URL).Send("Header", report.Report{Groups: []report.Group{{Domains: domains}}}); err != nil {
+		t.Fatal(err)
+	}
+
+	fields := 0
+	for _, message := range messages {
+		blocks := len(message.Blocks)
+		for _, attachment := range message.Attachments {
+			blocks += len(attachment.Blocks)
+			for _, block := range attachment.Blocks {
+				fields += len(block.Fields)
+			}
+		}
+		if blocks > slackBlockLimit {
+			t.Errorf("message exceeds block limit: %d", blocks)
+		}
+	}
+	if fields != len(domains) {
+		t.Errorf("expected %d fields, got %d", len(domains), fields)
+	}
+}
@@ -0,0 +1,153 @@
+package channels
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// htmlToken matches a tag or an entity at the start of Telegram HTML.
+var htmlToken = regexp.MustCompile(`^(?:<(/?)([a-zA-Z][a-zA-Z0-9-]*)[^<>]*>|&#?[a-zA-Z0-9]+;)`)
+
+// splitBlocks joins blocks with an empty line into messages no longer than limit characters.
+// Blocks are kept whole when possible; an oversized block is split on line boundaries
+// and an oversized line is cut.
+func splitBlocks(blocks []string, limit int) []string {
+	return split(blocks, limit, cutLine)
+}
+
+// splitHTMLBlocks is splitBlocks for Telegram HTML: oversized lines are cut with cutHTMLLine.
+func splitHTMLBlocks(blocks []string, limit int) []string {
+	return split(blocks, limit, cutHTMLLine)
+}
+
+func split(blocks []string, limit int, cut func(line string, limit int) []string) []string {
+	var (
+		messages []string
+		current  string
+	)
+
+	flush := func() {
+		if current != "" {
+			messages = append(messages, current)
+			current = ""
+		}
+	}
+
+	appendPart := func(part, separator string) {
+		if current == "" {
+			current = part
+			return
+		}
+		if utf8.RuneCountInString(current)+utf8.RuneCountInString(separator+part) <= limit {
+			current += separator + part
+			return
+		}
+		flush()
+		current = part
+	}
+
+	for _, block := range blocks {
+		if utf8.RuneCountInString(block) <= limit {
+			appendPart(block, "\n\n")
+			continue
+		}
+
+		separator := "\n\n"
+		for _, line := range strings.Split(block, "\n") {
+			for _, part := range cut(line, limit) {
+				appendPart(part, separator)
+				separator = "\n"
+			}
+		}
+	}
+	flush()
+
+	return messages
+}
+
+// cutLine splits a single line into parts no longer than limit characters.
+func cutLine(line string, limit int) []string {
+	if utf8.RuneCountInString(line) <= limit {
+		return []string{line}
+	}
+
+	var parts []string
+	runes := []rune(line)
+	for len(runes) > limit {
+		parts = append(parts, string(runes[:limit]))
+		runes = runes[limit:]
+	}
+	return append(parts, string(runes))
+}
+
+// cutHTMLLine splits a line of Telegram HTML into parts no longer than limit characters. Tags and entities
+// are never cut; tags open at a cut are closed at the end of the part and opened again in the next one.
+func cutHTMLLine(line string, limit int) []string {
+	if utf8.RuneCountInString(line) <= limit {
+		return []string{line}
+	}
+
+	type openTag struct{ name, tag string }
+
+	var (
+		parts []string
+		open  []openTag
+		part  strings.Builder
+		size  int
+		// reopened is the size of the tags opened again at the start of the part.
+		reopened int
+	)
+
+	closing := func(open []openTag) string {
+		var tags string
+		for i := len(open) - 1; i >= 0; i-- {
+			tags += "</" + open[i].name + ">"
+		}
+		return tags
+	}
+
+	for rest := line; rest != ""; {
+		_, n := utf8.DecodeRuneInString(rest)
+		token := rest[:n]
+
+		next := open
+		if match := htmlToken.FindStringSubmatch(rest); match != nil {
+			token = match[0]
+			if name := strings.ToLower(match[2]); name != "" && match[1] == "" {
+				next = append(slices.Clone(open), openTag{name: name, tag: token})
+			} else if name != "" {
+				if i := slices.IndexFunc(open, func(tag openTag) bool { return tag.name == name }); i != -1 {
+					next = slices.Delete(slices.Clone(open), i, i+1)
+				}
+			}
+		}
+		rest = rest[len(token):]
+
+		tokenSize := utf8.RuneCountInString(token)
+		if size+tokenSize+len(closing(next)) > limit && size > reopened {
+			parts = append(parts, part.String()+closing(open))
+			part.Reset()
+			for _, tag := range open {
+				part.WriteString(tag.tag)
+			}
+			size = utf8.RuneCountInString(part.String())
+			reopened = size
+		}
+
+		part.WriteString(token)
+		size += tokenSize
+		open = next
+	}
+
+	return append(parts, part.String())
+}
+
+// truncate shortens s to at most limit characters, marking the cut with an ellipsis.
+func truncate(s string, limit int) string {
+	if utf8.RuneCountInString(s) <= limit {
+		return s
+	}
+	return string([]rune(s)[:limit-1]) + "…"
+}
@@ -0,0 +1,84 @@
+package channels
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestSplitBlocks_SingleMessage(t *testing.T) {
+	messages := splitBlocks([]string{"header", "group1\nline"}, 100)
+
+	if len(messages) != 1 {
+		t.Fatalf("expected 1 message, got %d", len(messages))
+	}
+	if messages[0] != "header\n\ngroup1\nline" {
+		t.Errorf("unexpected message: %q", messages[0])
+	}
+}
+
+func TestSplitBlocks_GroupBoundary(t *testing.T) {
+	messages := splitBlocks([]string{"aaaa\nbbbb", "cccc\ndddd"}, 12)
+
+	if len(messages) != 2 {
+		t.Fatalf("expected 2 messages, got %d: %q", len(messages), messages)
+	}
+	if messages[0] != "aaaa\nbbbb" || messages[1] != "cccc\ndddd" {
+		t.Errorf("groups should not be split: %q", messages)
+	}
+}
+
+func TestSplitBlocks_LineBoundary(t *testing.T) {
+	block := strings.Repeat("домен\n", 9) + "домен"
+	messages := splitBlocks([]string{block}, 20)
+
+	for _, message := range messages {
+		if utf8.RuneCountInString(message) > 20 {
+			t.Errorf("message exceeds limit: %q", message)
+		}
+		if strings.HasPrefix(message, "\n") || strings.HasSuffix(message, "\n") {
+			t.Errorf("message should be split on line boundary: %q", message)
+		}
+	}
+	if strings.Join(messages, "\n") != block {
+		t.Errorf("lines were lost: %q", messages)
+	}
+}
+
+func TestSplitBlocks_LongLine(t *testing.T) {
+	messages := splitBlocks([]string{strings.Repeat("ж", 25)}, 10)
+
+	if len(messages) != 3 {
+		t.Fatalf("expected 3 messages, got %d", len(messages))
+	}
+}
+
+func TestSplitHTMLBlocks_LongLine(t *testing.T) {
+	line := "<b>" + strings.Repeat("a&amp;b ", 10) + "</b> - <code>" + strings.Repeat("&lt;x&gt;", 5) + "</code>"
+
+	messages := splitHTMLBlocks([]string{line}, 20)
+
+	if len(messages) < 2 {
+		t.Fatalf("expected the line to be cut, got %q", messages)
+	}
+	var text string
+	for _, message := range messages {
+		if utf8.RuneCountInString(message) > 20 {
+			t.Errorf("message exceeds the limit: %q", message)
+		}
+		if strings.Count(message, "<b>") != strings.Count(message, "</b>") || strings.Count(message, "<code>") != strings.Count(message, "</code>") {
+			t.Errorf("tags are not balanced: %q", message)
+		}
+		if strings.Count(message, "&") != strings.Count(message, ";") {
+			t.Errorf("an entity is cut: %q", message)
+		}
+		text += message
+	}
+
+	// The text is kept once the tags added at the cuts are removed.
+	unwrapped := strings.NewReplacer("<b>", "", "</b>", "", "<code>", "", "</code>", "").Replace(text)
+	expected := strings.NewReplacer("<b>", "", "</b>", "", "<code>", "", "</code>", "").Replace(line)
+	if unwrapped != expected {
+		t.Errorf("text was lost: %q", unwrapped)
+	}
+}
@@ -0,0 +1,202 @@
+package channels
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"github.com/Kravets1996/kz-domain-monitor/internal/api"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// syslogSDID identifies the structured data element. 32473 is the private enterprise number reserved for examples (RFC 5612).
+	syslogSDID = "kzdomain@32473"
+	// syslogLocalSocket is the socket of the local syslog daemon.
+	syslogLocalSocket = "/dev/log"
+)
+
+// Syslog severities (RFC 5424, section 6.2.1).
+const (
+	syslogCritical = 2
+	syslogError    = 3
+	syslogWarning  = 4
+	syslogInfo     = 6
+)
+
+type SyslogChannel struct {
+	network  string
+	address  string
+	facility int
+	appName  string
+	caFile   string
+}
+
+// NewSyslogChannel creates a channel that sends RFC 5424 messages over "udp", "tcp" or "tls" to address,
+// or to the local syslog daemon when network is empty.
+func NewSyslogChannel(network, address string, facility int, appName, caFile string) *SyslogChannel {
+	return &SyslogChannel{network: network, address: address, facility: facility, appName: appName, caFile: caFile}
+}
+
+// Send writes one message per domain result.
+func (s *SyslogChannel) Send(domains []api.Domain) error {
+	conn, err := s.dial()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	hostname, _ := os.Hostname()
+
+	for _, domain := range domains {
+		message := formatSyslogMessage(domain, s.facility, hostname, s.appName, time.Now())
+
+		// Stream transports need octet-counting framing (RFC 6587, RFC 5425); datagrams carry one message each.
+		if s.network == "tcp" || s.network == "tls" {
+			message = strconv.Itoa(len(message)) + " " + message
+		}
+
+		if _, err := conn.Write([]byte(message)); err != nil {
+			return fmt.Errorf("syslog: write failed: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *SyslogChannel) dial() (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+
+	var (
+		conn net.Conn
+		err  error
+	)
+	switch s.network {
+	case "":
+		conn, err = dialer.Dial("unixgram", syslogLocalSocket)
+	case "udp", "tcp":
+		conn, err = dialer.Dial(s.network, s.address)
+	case "tls":
+		var tlsConfig *tls.Config
+		tlsConfig, err = s.tlsConfig()
+		if err != nil {
+			return nil, err
+		}
+		conn, err = tls.DialWithDialer(dialer, "tcp", s.address, tlsConfig)
+	default:
+		return nil, fmt.Errorf("syslog: unknown network %q", s.network)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("syslog: dial failed: %w", err)
+	}
+
+	return conn, nil
+}
+
+func (s *SyslogChannel) tlsConfig() (*tls.Config, error) {
+	host, _, err := net.SplitHostPort(s.address)
+	if err != nil {
+		return nil, fmt.Errorf("syslog: invalid address: %w", err)
+	}
+
+	tlsConfig := &tls.Config{ServerName: host}
+	if s.caFile != "" {
+		pem, err := os.ReadFile(s.caFile)
+		if err != nil {
+			return nil, fmt.Errorf("syslog: failed to read CA file: %w", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("syslog: no certificates found in CA file %s", s.caFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	return tlsConfig, nil
+}
+
+// formatSyslogMessage renders a domain result as an RFC 5424 message with structured data, e.g.
+// <28>1 2025-01-01T00:00:00Z host kz-domain-monitor 42 domain [kzdomain@32473 domain="example.kz" ...] BOM⚠️ 3 дня - example.kz
+// The text starts with the UTF-8 BOM, which marks it as MSG-UTF8.
+func formatSyslogMessage(domain api.Domain, facility int, hostname, appName string, timestamp time.Time) string {
+	priority := facility*8 + syslogSeverity(domain.GetSeverity())
+
+	return fmt.Sprintf("<%d>1 %s %s %s %d domain %s \xEF\xBB\xBF%s",
+		priority,
+		timestamp.Format("2006-01-02T15:04:05.000000Z07:00"),
+		syslogHeaderField(hostname),
+		syslogHeaderField(appName),
+		os.Getpid(),
+		syslogStructuredData(domain),
+		domain.GetMessage(),
+	)
+}
+
+func syslogSeverity(severity api.Severity) int {
+	switch severity {
+	case api.SeverityWarning:
+		return syslogWarning
+	case api.SeverityError:
+		return syslogError
+	case api.SeverityCritical:
+		return syslogCritical
+	default:
+		return syslogInfo
+	}
+}
+
+func syslogStructuredData(domain api.Domain) string {
+	var sd strings.Builder
+	sd.WriteString("[" + syslogSDID)
+
+	for _, field := range domainFields(domain) {
+		sd.WriteString(" " + strings.ToLower(field.key) + `="` + syslogEscape(field.value) + `"`)
+	}
+
+	sd.WriteString("]")
+	return sd.String()
+}
+
+// syslogHeaderField replaces an empty header field with the nil value "-".
+func syslogHeaderField(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return strings.ReplaceAll(value, " ", "_")
+}
+
+// syslogEscape escapes the characters that must be escaped in SD parameter values.
+func syslogEscape(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`).Replace(value)
+}
+
+type domainField struct {
+	key   string
+	value string
+}
+
+// domainFields returns the structured fields of a domain result, shared by syslog and journald.
+func domainFields(domain api.Domain) []domainField {
+	fields := []domainField{
+		{"DOMAIN", domain.Name},
+		{"SEVERITY", domain.GetSeverity().String()},
+		{"AVAILABLE", strconv.FormatBool(domain.IsAvailable)},
+	}
+	if domain.ExpirationDate != nil {
+		fields = append(fields,
+			domainField{"DAYS_LEFT", strconv.FormatInt(domain.GetDaysToExpire(), 10)},
+			domainField{"EXPIRATION_DATE", domain.ExpiresAt().Format(time.RFC3339)},
+		)
+	}
+	if domain.Registrar != "" {
+		fields = append(fields, domainField{"REGISTRAR", domain.Registrar})
+	}
+	if domain.Error != nil {
+		fields = append(fields, domainField{"ERROR", domain.Error.Error()})
+	}
+	return fields
+}
@@ -0,0 +1,87 @@
+package channels
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"github.com/Kravets1996/kz-domain-monitor/internal/api"
+	"net"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFormatSyslogMessage(t *testing.T) {
+	expiring := time.Now().AddDate(0, 0, 3)
+	domain := api.Domain{Name: "example.kz", ExpirationDate: &expiring, Policy: testPolicy, Registrar: `PS "Internet" [KZ]`}
+	timestamp := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	message := formatSyslogMessage(domain, 3, "web 1", "kz-domain-monitor", timestamp)
+
+	prefix := fmt.Sprintf("<28>1 2025-01-02T03:04:05.000000Z web_1 kz-domain-monitor %d domain [kzdomain@32473 domain=\"example.kz\" severity=\"warning\"", os.Getpid())
+	if !strings.HasPrefix(message, prefix) {
+		t.Errorf("unexpected header:\n%s\nwant prefix:\n%s", message, prefix)
+	}
+	if !strings.Contains(message, ` days_left="3"`) || !strings.Contains(message, `registrar="PS \"Internet\" [KZ\]"`) {
+		t.Errorf("unexpected structured data: %s", message)
+	}
+	if !strings.HasSuffix(message, "] \xEF\xBB\xBF⚠️ 3 дня - example.kz") {
+		t.Errorf("unexpected message: %s", message)
+	}
+}
+
+func TestSyslogChannel_SendUDP(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	domains := []api.Domain{{Name: "free.kz", IsAvailable: true}, {Name: "broken.kz", Error: errors.New("timeout")}}
+	if err := NewSyslogChannel("udp", conn.LocalAddr().String(), 1, "app", "").Send(domains); err != nil {
+		t.Fatal(err)
+	}
+
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	buf := make([]byte, 2048)
+	for _, prefix := range []string{"<10>1 ", "<11>1 "} {
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasPrefix(string(buf[:n]), prefix) {
+			t.Errorf("expected %q prefix, got %s", prefix, buf[:n])
+		}
+	}
+}
+
+func TestSyslogChannel_SendTCPOctetCounting(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	received := make(chan string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		line, _ := bufio.NewReader(conn).ReadString('>')
+		received <- line
+	}()
+
+	domains := []api.Domain{{Name: "free.kz", IsAvailable: true}}
+	if err := NewSyslogChannel("tcp", listener.Addr().String(), 1, "app", "").Send(domains); err != nil {
+		t.Fatal(err)
+	}
+
+	hostname, _ := os.Hostname()
+	message := formatSyslogMessage(domains[0], 1, hostname, "app", time.Now())
+	if got := <-received; got != fmt.Sprintf("%d <10>", len(message)) {
+		t.Errorf("expected octet-counting frame, got %q", got)
+	}
+}
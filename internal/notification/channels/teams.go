@@ -0,0 +1,177 @@
+package channels
+
+import (
+	"encoding/json"
+	"github.com/Kravets1996/kz-domain-monitor/internal/api"
+	"github.com/Kravets1996/kz-domain-monitor/internal/report"
+)
+
+// teamsCardSizeLimit is the maximum size of a message with an Adaptive Card accepted by Teams (28 KB),
+// with some room left for the envelope.
+const teamsCardSizeLimit = 27 * 1024
+
+type TeamsChannel struct {
+	webhookURL string
+}
+
+type teamsMessage struct {
+	Type        string            `json:"type"`
+	Attachments []teamsAttachment `json:"attachments"`
+}
+
+type teamsAttachment struct {
+	ContentType string    `json:"contentType"`
+	ContentURL  *string   `json:"contentUrl"`
+	Content     teamsCard `json:"content"`
+}
+
+type teamsCard struct {
+	Schema  string         `json:"$schema"`
+	Type    string         `json:"type"`
+	Version string         `json:"version"`
+	Body    []teamsElement `json:"body"`
+	MSTeams map[string]any `json:"msteams,omitempty"`
+}
+
+// teamsElement is a subset of Adaptive Card elements: TextBlock, Container and FactSet.
+type teamsElement struct {
+	Type      string         `json:"type"`
+	Text      string         `json:"text,omitempty"`
+	Weight    string         `json:"weight,omitempty"`
+	Size      string         `json:"size,omitempty"`
+	Color     string         `json:"color,omitempty"`
+	Wrap      bool           `json:"wrap,omitempty"`
+	Spacing   string         `json:"spacing,omitempty"`
+	Style     string         `json:"style,omitempty"`
+	Bleed     bool           `json:"bleed,omitempty"`
+	Items     []teamsElement `json:"items,omitempty"`
+	Facts     []teamsFact    `json:"facts,omitempty"`
+	Separator bool           `json:"separator,omitempty"`
+}
+
+type teamsFact struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+}
+
+// NewTeamsChannel creates a channel that posts Adaptive Cards to a Teams or Power Automate workflow webhook.
+func NewTeamsChannel(webhookURL string) *TeamsChannel {
+	return &TeamsChannel{webhookURL: webhookURL}
+}
+
+// Send posts the report as an Adaptive Card with a header colored by the worst status and a FactSet per group.
+// Reports exceeding the card size limit are split into several cards.
+func (t *TeamsChannel) Send(header string, rep report.Report) error {
+	for _, card := range teamsCards(header, rep) {
+		if err := t.post(card); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *TeamsChannel) post(card teamsCard) error {
+	_, err := postJSON("teams", t.webhookURL, newTeamsMessage(card), nil)
+	return err
+}
+
+func newTeamsMessage(card teamsCard) teamsMessage {
+	return teamsMessage{
+		Type: "message",
+		Attachments: []teamsAttachment{{
+			ContentType: "application/vnd.microsoft.card.adaptive",
+			Content:     card,
+		}},
+	}
+}
+
+func newTeamsCard(body []teamsElement) teamsCard {
+	return teamsCard{
+		Schema:  "http://adaptivecards.io/schemas/adaptive-card.json",
+		Type:    "AdaptiveCard",
+		Version: "1.4",
+		Body:    body,
+		MSTeams: map[string]any{"width": "Full"},
+	}
+}
+
+// teamsCards renders the report into one or more cards that fit into the size limit.
+// Every card starts with the header; a group that does not fit is continued in the next card.
+func teamsCards(header string, rep report.Report) []teamsCard {
+	headerElement := teamsElement{
+		Type:  "Container",
+		Style: teamsContainerStyle(rep.Severity()),
+		Bleed: true,
+		Items: []teamsElement{
+			{Type: "TextBlock", Text: header, Weight: "Bolder", Size: "Large", Wrap: true},
+			{Type: "TextBlock", Text: severityLabel(rep.Printer, rep.Severity()), Spacing: "None", Wrap: true},
+		},
+	}
+	for _, notice := range rep.NoticeLines() {
+		headerElement.Items = append(headerElement.Items, teamsElement{Type: "TextBlock", Text: notice, Color: "Attention", Wrap: true})
+	}
+
+	var cards []teamsCard
+	current := newTeamsCard([]teamsElement{headerElement})
+
+	for _, group := range rep.Groups {
+		if len(group.Domains) == 0 {
+			continue
+		}
+
+		var title []teamsElement
+		if group.Title != "" {
+			title = []teamsElement{{Type: "TextBlock", Text: group.Title, Weight: "Bolder", Wrap: true, Separator: true, Spacing: "Medium"}}
+		}
+
+		// size is an upper bound of the payload size: the card is marshalled once per group and card, and
+		// every fact adds its own size and a comma, as JSON escaping does not depend on the surroundings.
+		var size int
+		startGroup := func() {
+			current.Body = append(current.Body, title...)
+			current.Body = append(current.Body, teamsElement{Type: "FactSet"})
+			size = teamsPayloadSize(current) + len(`,"facts":[]`)
+		}
+		startGroup()
+
+		for _, domain := range group.Domains {
+			fact := teamsFact{Title: domain.Name, Value: domainStatus(rep.Printer, domain)}
+			factJSON, _ := json.Marshal(fact)
+
+			factSet := &current.Body[len(current.Body)-1]
+			if size+len(factJSON)+1 > teamsCardSizeLimit && (len(factSet.Facts) > 0 || len(current.Body) > 1+len(title)+1) {
+				// Continue the group in a new card that repeats the header and group title.
+				if len(factSet.Facts) == 0 {
+					current.Body = current.Body[:len(current.Body)-1-len(title)]
+				}
+				cards = append(cards, current)
+
+				current = newTeamsCard([]teamsElement{headerElement})
+				startGroup()
+				factSet = &current.Body[len(current.Body)-1]
+			}
+
+			factSet.Facts = append(factSet.Facts, fact)
+			size += len(factJSON) + 1
+		}
+	}
+
+	return append(cards, current)
+}
+
+// teamsPayloadSize returns the size of the message with the card.
+func teamsPayloadSize(card teamsCard) int {
+	payload, _ := json.Marshal(newTeamsMessage(card))
+	return len(payload)
+}
+
+func teamsContainerStyle(severity api.Severity) string {
+	switch severity {
+	case api.SeverityOk:
+		return "good"
+	case api.SeverityWarning:
+		return "warning"
+	default:
+		return "attention"
+	}
+}
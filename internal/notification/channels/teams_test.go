@@ -0,0 +1,110 @@
+package channels
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/Kravets1996/kz-domain-monitor/internal/api"
+	"github.com/Kravets1996/kz-domain-monitor/internal/report"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestTeamsChannel_Send(t *testing.T) {
+	var messages []teamsMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(body) > teamsCardSizeLimit {
+			t.Errorf("payload exceeds card size limit: %d", len(body))
+		}
+
+		var message teamsMessage
+		if err := json.Unmarshal(body, &message); err != nil {
+			t.Fatal(err)
+		}
+		messages = append(messages, message)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	expiration := time.Now().AddDate(0, 0, 90)
+	expiring := time.Now().AddDate(0, 0, 3)
+
+	var domains []api.Domain
+	for i := 0; i < 1000; i++ {
+		domains = append(domains, api.Domain{Name: fmt.Sprintf("example-%d.kz", i), ExpirationDate: &expiration, Policy: testPolicy})
+	}
+
+	rep := report.Report{Groups: []report.Group{
+		{Title: "Сайты", Domains: domains},
+		{Title: "Сервисы", Domains: []api.Domain{{Name: "expiring.kz", ExpirationDate: &expiring, Policy: testPolicy}}},
+	}}
+
+	if err := NewTeamsChannel(server.URL).Send("Header", rep); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(messages) < 2 {
+		t.Fatalf("expected report to be split, got %d cards", len(messages))
+	}
+
+	facts := 0
+	for _, message := range messages {
+		card := message.Attachments[0].Content
+		if card.Type != "AdaptiveCard" || card.Body[0].Style != "warning" {
+			t.Errorf("card should start with a header colored by the worst status: %+v", card.Body[0])
+		}
+		for _, element := range card.Body {
+			facts += len(element.Facts)
+		}
+	}
+	if facts != len(domains)+1 {
+		t.Errorf("expected %d facts, got %d", len(domains)+1, facts)
+	}
+
+	first := messages[0].Attachments[0].Content.Body
+	if first[1].Text != "Сайты" || first[2].Facts[0] != (teamsFact{Title: "example-0.kz", Value: "✅ 90 дней"}) {
+		t.Errorf("unexpected group: %+v %+v", first[1], first[2].Facts[0])
+	}
+}
+
+func TestTeamsCards_SizeLimit(t *testing.T) {
+	expiration := time.Now().AddDate(0, 0, 90)
+
+	var domains []api.Domain
+	for i := 0; i < 3000; i++ {
+		domains = append(domains, api.Domain{Name: fmt.Sprintf("domain-%04d.kz", i), ExpirationDate: &expiration, Policy: testPolicy})
+	}
+	rep := report.Report{Groups: []report.Group{{Title: "Пусто"}, {Title: "Сайты", Domains: domains}}}
+
+	cards := teamsCards("Header", rep)
+	if len(cards) < 2 {
+		t.Fatalf("expected the report to be split, got %d card", len(cards))
+	}
+
+	count := 0
+	for _, card := range cards {
+		if size := teamsPayloadSize(card); size > teamsCardSizeLimit {
+			t.Errorf("card of %d bytes exceeds the limit", size)
+		}
+		for _, element := range card.Body {
+			if element.Text == "Пусто" {
+				t.Error("a group without domains should be skipped")
+			}
+			if element.Type == "FactSet" {
+				if len(element.Facts) == 0 {
+					t.Error("empty FactSet")
+				}
+				count += len(element.Facts)
+			}
+		}
+	}
+	if count != len(domains) {
+		t.Errorf("expected %d domains in cards, got %d", len(domains), count)
+	}
+}
@@ -2,29 +2,55 @@ package channels
 
 import (
 	"fmt"
+	"github.com/Kravets1996/kz-domain-monitor/internal/api"
+	"github.com/Kravets1996/kz-domain-monitor/internal/i18n"
+	"github.com/Kravets1996/kz-domain-monitor/internal/report"
+	"html"
 	"net/http"
 	"net/url"
 	"strings"
 )
 
+// telegramMessageLimit is the maximum length of a single Telegram message.
+const telegramMessageLimit = 4096
+
 type TelegramChannel struct {
+	apiURL   string
 	botToken string
 	chatID   string
 }
 
 func NewTelegramChannel(botToken string, chatID string) *TelegramChannel {
 	return &TelegramChannel{
+		apiURL:   "https://api.telegram.org",
 		botToken: botToken,
 		chatID:   chatID,
 	}
 }
 
-func (t TelegramChannel) Send(message string, silent bool) (err error) {
-	apiURL := "https://api.telegram.org/bot" + t.botToken + "/sendMessage"
+// Send renders the report as HTML and sends it, split into several messages if it exceeds the Telegram limit.
+func (t TelegramChannel) Send(header string, rep report.Report, silent bool) error {
+	blocks, err := reportBlocks(header, rep, formatTelegramBlocks)
+	if err != nil {
+		return err
+	}
+
+	for _, message := range splitHTMLBlocks(blocks, telegramMessageLimit) {
+		if err := t.sendMessage(message, silent); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (t TelegramChannel) sendMessage(message string, silent bool) (err error) {
+	apiURL := t.apiURL + "/bot" + t.botToken + "/sendMessage"
 
 	data := url.Values{}
 	data.Set("chat_id", t.chatID)
 	data.Set("text", message)
+	data.Set("parse_mode", "HTML")
 	if silent {
 		data.Set("disable_notification", "true")
 	}
@@ -44,3 +70,41 @@ func (t TelegramChannel) Send(message string, silent bool) (err error) {
 
 	return nil
 }
+
+// FormatTelegramMessages renders the report as HTML messages that fit into the Telegram message limit.
+func FormatTelegramMessages(header string, rep report.Report) []string {
+	return splitHTMLBlocks(formatTelegramBlocks(header, rep), telegramMessageLimit)
+}
+
+// formatTelegramBlocks returns the header and every group of the report as separate HTML blocks.
+func formatTelegramBlocks(header string, rep report.Report) []string {
+	blocks := []string{html.EscapeString(header)}
+	if notices := noticeText(rep); notices != "" {
+		blocks = append(blocks, html.EscapeString(notices))
+	}
+
+	for _, group := range rep.Groups {
+		if len(group.Domains) == 0 {
+			continue
+		}
+
+		var lines []string
+		if group.Title != "" {
+			lines = append(lines, "<b>"+html.EscapeString(group.Title)+":</b>")
+		}
+		for _, domain := range group.Domains {
+			lines = append(lines, formatTelegramDomain(rep.Printer, domain))
+		}
+		blocks = append(blocks, strings.Join(lines, "\n"))
+	}
+
+	return blocks
+}
+
+func formatTelegramDomain(p i18n.Printer, domain api.Domain) string {
+	if domain.Error != nil || domain.ExpirationDate == nil || domain.IsAvailable {
+		return html.EscapeString(domain.Message(p))
+	}
+
+	return fmt.Sprintf("%s <code>%s</code> - %s", domain.GetIcon(), domain.Days(p), html.EscapeString(domain.Name))
+}
@@ -0,0 +1,141 @@
+package channels
+
+import (
+	"fmt"
+	"github.com/Kravets1996/kz-domain-monitor/internal/api"
+	"github.com/Kravets1996/kz-domain-monitor/internal/i18n"
+	"github.com/Kravets1996/kz-domain-monitor/internal/report"
+	"github.com/Kravets1996/kz-domain-monitor/internal/templates"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+// testPolicy is the policy of the domains in channel tests.
+var testPolicy = api.Policy{DaysToExpire: 15}
+
+func TestTelegramChannel_Send_HTML(t *testing.T) {
+	var texts []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("parse_mode") != "HTML" {
+			t.Errorf("expected parse_mode=HTML, got %q", r.FormValue("parse_mode"))
+		}
+		texts = append(texts, r.FormValue("text"))
+	}))
+	defer server.Close()
+
+	channel := NewTelegramChannel("token", "1")
+	channel.apiURL = server.URL
+
+	expiration := time.Now().AddDate(0, 0, 90)
+	rep := report.Report{Groups: []report.Group{{
+		Title:   "Сайты <prod>",
+		Domains: []api.Domain{{Name: "example.kz", ExpirationDate: &expiration, Policy: testPolicy}},
+	}}}
+
+	if err := channel.Send("Header", rep, true); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(texts) != 1 {
+		t.Fatalf("expected 1 message, got %d", len(texts))
+	}
+	if !strings.Contains(texts[0], "<b>Сайты &lt;prod&gt;:</b>") {
+		t.Errorf("group title should be bold and escaped: %q", texts[0])
+	}
+	if !strings.Contains(texts[0], "<code>90 дней</code> - example.kz") {
+		t.Errorf("days should be monospaced: %q", texts[0])
+	}
+}
+
+func TestFormatTelegramMessages_NoticesAndTitleKey(t *testing.T) {
+	expiration := time.Now().AddDate(0, 0, 90)
+	rep := report.Report{
+		Groups:  []report.Group{{TitleKey: "import.group", Domains: []api.Domain{{Name: "example.kz", ExpirationDate: &expiration, Policy: testPolicy}}}},
+		Notices: []report.Notice{{Key: "import.failed", Args: []any{"<timeout>"}}},
+	}.In("en")
+
+	messages := FormatTelegramMessages("Header", rep)
+
+	if len(messages) != 1 {
+		t.Fatalf("expected 1 message, got %d", len(messages))
+	}
+	if !strings.Contains(messages[0], "⚠️ Failed to load the ps.kz account domains: &lt;timeout&gt;") {
+		t.Errorf("notice should be rendered in the report language and escaped: %q", messages[0])
+	}
+	if !strings.Contains(messages[0], "<b>Not in the configuration (ps.kz account):</b>") {
+		t.Errorf("group title should be rendered in the report language: %q", messages[0])
+	}
+}
+
+func TestTelegramChannel_Send_Template(t *testing.T) {
+	var texts []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		texts = append(texts, r.FormValue("text"))
+	}))
+	defer server.Close()
+
+	channel := NewTelegramChannel("token", "1")
+	channel.apiURL = server.URL
+
+	path := filepath.Join(t.TempDir(), "telegram.tmpl")
+	content := `{{define "header"}}{{.Header}}: {{.Stats.Problems}}/{{.Stats.Total}}{{end}}
+{{define "domain"}}{{.Name}} ({{.Group}}) [{{.Severity}}] {{.DaysLeft}}{{end}}`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	tmpl, err := templates.Load(path, true, i18n.New("ru"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expiration := time.Now().AddDate(0, 0, 3)
+	rep := report.Report{
+		Groups:   []report.Group{{Title: "Сайты <prod>", Domains: []api.Domain{{Name: "example.kz", ExpirationDate: &expiration, Policy: testPolicy}}}},
+		Template: tmpl,
+	}
+
+	if err := channel.Send("Header", rep, true); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "Header: 1/1\n\n<b>Сайты &lt;prod&gt;:</b>\nexample.kz (Сайты &lt;prod&gt;) [warning] 3"
+	if len(texts) != 1 || texts[0] != expected {
+		t.Errorf("unexpected messages: %q", texts)
+	}
+}
+
+func TestTelegramChannel_Send_Split(t *testing.T) {
+	var texts []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		texts = append(texts, r.FormValue("text"))
+	}))
+	defer server.Close()
+
+	channel := NewTelegramChannel("token", "1")
+	channel.apiURL = server.URL
+
+	expiration := time.Now().AddDate(0, 0, 90)
+	var domains []api.Domain
+	for i := 0; i < 300; i++ {
+		domains = append(domains, api.Domain{Name: fmt.Sprintf("example-%d.kz", i), ExpirationDate: &expiration, Policy: testPolicy})
+	}
+
+	if err := channel.Send("Header", report.Report{Groups: []report.Group{{Domains: domains}}}, false); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(texts) < 2 {
+		t.Fatalf("expected report to be split, got %d messages", len(texts))
+	}
+	for _, text := range texts {
+		if utf8.RuneCountInString(text) > telegramMessageLimit {
+			t.Errorf("message exceeds limit: %d", utf8.RuneCountInString(text))
+		}
+	}
+}
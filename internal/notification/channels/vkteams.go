@@ -0,0 +1,68 @@
+package channels
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/Kravets1996/kz-domain-monitor/internal/report"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// vkTeamsMessageLimit is the maximum length of a single VK Teams message.
+const vkTeamsMessageLimit = 4096
+
+type VKTeamsChannel struct {
+	apiURL   string
+	botToken string
+	chatID   string
+}
+
+type vkTeamsResponse struct {
+	OK          bool   `json:"ok"`
+	Description string `json:"description"`
+}
+
+// NewVKTeamsChannel creates a channel for the VK Teams Bot API, e.g. https://api.internal.myteam.mail.ru/bot/v1
+// for corporate installations.
+func NewVKTeamsChannel(apiURL, botToken, chatID string) *VKTeamsChannel {
+	return &VKTeamsChannel{apiURL: strings.TrimSuffix(apiURL, "/"), botToken: botToken, chatID: chatID}
+}
+
+// Send posts the report with messages/sendText. VK Teams supports the same HTML subset as Telegram.
+func (v *VKTeamsChannel) Send(header string, rep report.Report) error {
+	blocks, err := reportBlocks(header, rep, formatTelegramBlocks)
+	if err != nil {
+		return err
+	}
+
+	for _, message := range splitHTMLBlocks(blocks, vkTeamsMessageLimit) {
+		if err := v.sendText(message); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v *VKTeamsChannel) sendText(text string) error {
+	data := url.Values{}
+	data.Set("token", v.botToken)
+	data.Set("chatId", v.chatID)
+	data.Set("text", text)
+	data.Set("parseMode", "HTML")
+
+	body, err := sendRequest("vkteams", http.MethodPost, v.apiURL+"/messages/sendText", "application/x-www-form-urlencoded", []byte(data.Encode()), nil)
+	if err != nil {
+		return err
+	}
+
+	var response vkTeamsResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return fmt.Errorf("vkteams: failed to parse response: %w", err)
+	}
+	if !response.OK {
+		return fmt.Errorf("vkteams: api error: %s", response.Description)
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package channels
+
+import (
+	"fmt"
+	"github.com/Kravets1996/kz-domain-monitor/internal/api"
+	"github.com/Kravets1996/kz-domain-monitor/internal/report"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestVKTeamsChannel_Send(t *testing.T) {
+	var form url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/bot/v1/messages/sendText" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Fatal(err)
+		}
+		form = r.PostForm
+		fmt.Fprint(w, `{"ok": true, "msgId": "1"}`)
+	}))
+	defer server.Close()
+
+	expiring := time.Now().AddDate(0, 0, 3)
+	rep := report.Report{Groups: []report.Group{{Domains: []api.Domain{{Name: "example.kz", ExpirationDate: &expiring, Policy: testPolicy}}}}}
+
+	if err := NewVKTeamsChannel(server.URL+"/bot/v1/", "token", "team@chat.agent").Send("Header", rep); err != nil {
+		t.Fatal(err)
+	}
+
+	if form.Get("token") != "token" || form.Get("chatId") != "team@chat.agent" || form.Get("parseMode") != "HTML" {
+		t.Errorf("unexpected form: %v", form)
+	}
+	if form.Get("text") != "Header\n\n⚠️ <code>3 дня</code> - example.kz" {
+		t.Errorf("unexpected text: %q", form.Get("text"))
+	}
+}
+
+func TestVKTeamsChannel_SendError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"ok": false, "description": "Invalid token"}`)
+	}))
+	defer server.Close()
+
+	rep := report.Report{Groups: []report.Group{{Domains: []api.Domain{{Name: "free.kz", IsAvailable: true}}}}}
+
+	if err := NewVKTeamsChannel(server.URL, "token", "chat").Send("Header", rep); err == nil {
+		t.Error("expected api error")
+	}
+}
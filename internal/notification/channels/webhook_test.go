@@ -0,0 +1,56 @@
+package channels
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/Kravets1996/kz-domain-monitor/internal/api"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWebhookChannel_SendDomains(t *testing.T) {
+	var body map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+	}))
+	defer server.Close()
+
+	expiring := time.Now().AddDate(0, 0, 3)
+	domains := []api.Domain{
+		{Name: "example.kz", ExpirationDate: &expiring, Policy: testPolicy, Registrar: "PS Internet Company"},
+		{Name: "broken.kz", Error: errors.New("timeout")},
+	}
+	groupTitle := func(domain string) string {
+		if domain == "example.kz" {
+			return "Сайты"
+		}
+		return ""
+	}
+
+	if err := NewWebhookChannel(server.URL).Send(true, "message", NewDomainEvents(domains, groupTitle)); err != nil {
+		t.Fatal(err)
+	}
+
+	if body["message"] != "message" || body["hasError"] != true {
+		t.Errorf("unexpected body: %v", body)
+	}
+
+	events, _ := body["domains"].([]any)
+	if len(events) != 2 {
+		t.Fatalf("expected 2 domains, got %v", body["domains"])
+	}
+
+	first := events[0].(map[string]any)
+	if first["domain"] != "example.kz" || first["group"] != "Сайты" || first["severity"] != "warning" || first["daysLeft"] != float64(3) || first["registrar"] != "PS Internet Company" {
+		t.Errorf("unexpected event: %v", first)
+	}
+
+	second := events[1].(map[string]any)
+	if second["severity"] != "error" || second["error"] != "timeout" || second["daysLeft"] != nil {
+		t.Errorf("unexpected event: %v", second)
+	}
+}
@@ -2,24 +2,42 @@ package notification
 
 import (
 	"fmt"
-	"kz-domain-monitor/internal/config"
-	"kz-domain-monitor/internal/notification/channels"
-	"strings"
+	"github.com/Kravets1996/kz-domain-monitor/internal/api"
+	"github.com/Kravets1996/kz-domain-monitor/internal/config"
+	"github.com/Kravets1996/kz-domain-monitor/internal/notification/channels"
+	"github.com/Kravets1996/kz-domain-monitor/internal/report"
+	"github.com/Kravets1996/kz-domain-monitor/internal/state"
 )
 
-func SendNotification(results []string, hasError bool) {
+// SendNotification syncs alerting integrations with the check results and sends the report to
+// the enabled channels. Successful runs are only sent with SEND_ON_SUCCESS.
+func SendNotification(rep report.Report) {
 	cfg := config.GetConfig()
 
-	if len(results) == 0 {
+	syncAlerts(cfg, rep)
+	logResults(cfg, rep)
+	publishEvents(cfg, rep)
+
+	if !rep.HasError && !cfg.SendSuccess {
 		return
 	}
 
-	message := "До истечения домена осталось: \n\n"
+	if rep.IsEmpty() {
+		return
+	}
 
-	message = message + strings.Join(results, "\n")
+	hasError := rep.HasError
+
+	// in returns the report and its header in the language and with the template of the channel.
+	in := func(channel string) (string, report.Report) {
+		localized := rep.In(cfg.LanguageFor(channel))
+		localized.Template = cfg.Templates[channel]
+		return localized.Printer.T("header"), localized
+	}
 
 	if cfg.Telegram.Enabled {
-		err := channels.NewTelegramChannel(cfg.Telegram.BotToken, cfg.Telegram.ChatID).Send(message, !hasError)
+		header, rep := in("telegram")
+		err := channels.NewTelegramChannel(cfg.Telegram.BotToken, cfg.Telegram.ChatID).Send(header, rep, !hasError)
 		if err != nil {
 			fmt.Println(err)
 			panic(err)
@@ -27,7 +45,7 @@ func SendNotification(results []string, hasError bool) {
 	}
 
 	if cfg.Slack.Enabled {
-		err := channels.NewSlackChannel(cfg.Slack.WebhookURL).Send(message)
+		err := sendSlack(cfg, rep.In(cfg.LanguageFor("slack")))
 		if err != nil {
 			fmt.Println(err)
 			panic(err)
@@ -35,7 +53,21 @@ func SendNotification(results []string, hasError bool) {
 	}
 
 	if cfg.Email.Enabled {
-		err := channels.NewEmailChannel(cfg.Email.Host, cfg.Email.Port, cfg.Email.Username, cfg.Email.Password, cfg.Email.From, cfg.Email.To).Send(message)
+		header, rep := in("email")
+		err := channels.NewEmailChannel(channels.EmailOptions{
+			Host:               cfg.Email.Host,
+			Port:               cfg.Email.Port,
+			Username:           cfg.Email.Username,
+			Password:           cfg.Email.Password,
+			From:               cfg.Email.From,
+			To:                 cfg.Email.To,
+			Subject:            cfg.Email.Subject,
+			AttachCSV:          cfg.Email.AttachCSV,
+			Security:           cfg.Email.Security,
+			Auth:               cfg.Email.Auth,
+			CAFile:             cfg.Email.CAFile,
+			InsecureSkipVerify: cfg.Email.InsecureSkipVerify,
+		}).Send(header, rep)
 		if err != nil {
 			fmt.Println(err)
 			panic(err)
@@ -43,10 +75,252 @@ func SendNotification(results []string, hasError bool) {
 	}
 
 	if cfg.Webhook.Enabled {
-		err := channels.NewWebhookChannel(cfg.Webhook.URL).Send(hasError, message)
+		header, rep := in("webhook")
+		message, err := channels.FormatPlainMessage(header, rep)
+		if err == nil {
+			err = channels.NewWebhookChannel(cfg.Webhook.URL).Send(hasError, message, channels.NewDomainEvents(rep.Domains(), cfg.GroupTitle))
+		}
+		if err != nil {
+			fmt.Println(err)
+			panic(err)
+		}
+	}
+
+	if cfg.Discord.Enabled {
+		header, rep := in("discord")
+		err := channels.NewDiscordChannel(cfg.Discord.WebhookURL).Send(header, rep)
+		if err != nil {
+			fmt.Println(err)
+			panic(err)
+		}
+	}
+
+	if cfg.Teams.Enabled {
+		header, rep := in("teams")
+		err := channels.NewTeamsChannel(cfg.Teams.WebhookURL).Send(header, rep)
 		if err != nil {
 			fmt.Println(err)
 			panic(err)
 		}
 	}
+
+	if cfg.Mattermost.Enabled {
+		header, rep := in("mattermost")
+		err := channels.NewMattermostChannel(cfg.Mattermost.WebhookURL, cfg.Mattermost.Channel, cfg.Mattermost.Username, cfg.Mattermost.IconURL).Send(header, rep)
+		if err != nil {
+			fmt.Println(err)
+			panic(err)
+		}
+	}
+
+	if cfg.RocketChat.Enabled {
+		header, rep := in("rocketchat")
+		err := channels.NewRocketChatChannel(cfg.RocketChat.WebhookURL, cfg.RocketChat.Channel, cfg.RocketChat.Alias, cfg.RocketChat.AvatarURL).Send(header, rep)
+		if err != nil {
+			fmt.Println(err)
+			panic(err)
+		}
+	}
+
+	if cfg.Matrix.Enabled {
+		header, rep := in("matrix")
+		err := channels.NewMatrixChannel(cfg.Matrix.HomeserverURL, cfg.Matrix.AccessToken, cfg.Matrix.RoomID).Send(header, rep)
+		if err != nil {
+			fmt.Println(err)
+			panic(err)
+		}
+	}
+
+	if cfg.Ntfy.Enabled {
+		header, rep := in("ntfy")
+		err := channels.NewNtfyChannel(cfg.Ntfy.TopicURL, cfg.Ntfy.Token).Send(header, rep, !hasError)
+		if err != nil {
+			fmt.Println(err)
+			panic(err)
+		}
+	}
+
+	if cfg.Gotify.Enabled {
+		header, rep := in("gotify")
+		err := channels.NewGotifyChannel(cfg.Gotify.URL, cfg.Gotify.AppToken, cfg.Gotify.Priority).Send(header, rep, !hasError)
+		if err != nil {
+			fmt.Println(err)
+			panic(err)
+		}
+	}
+
+	if cfg.Pushover.Enabled {
+		header, rep := in("pushover")
+		err := channels.NewPushoverChannel(cfg.Pushover.AppToken, cfg.Pushover.UserKey).Send(header, rep, !hasError)
+		if err != nil {
+			fmt.Println(err)
+			panic(err)
+		}
+	}
+
+	if cfg.Bitrix24.Enabled {
+		header, rep := in("bitrix24")
+		err := channels.NewBitrix24Channel(cfg.Bitrix24.WebhookURL, cfg.Bitrix24.DialogID).Send(header, rep)
+		if err != nil {
+			fmt.Println(err)
+			panic(err)
+		}
+	}
+
+	if cfg.VKTeams.Enabled {
+		header, rep := in("vkteams")
+		err := channels.NewVKTeamsChannel(cfg.VKTeams.APIURL, cfg.VKTeams.BotToken, cfg.VKTeams.ChatID).Send(header, rep)
+		if err != nil {
+			fmt.Println(err)
+			panic(err)
+		}
+	}
+}
+
+// sendSlack posts the report to the incoming webhook or, with a bot token,
+// to the Slack channel of every domain group (SLACK_CHANNEL by default).
+func sendSlack(cfg config.Config, rep report.Report) error {
+	header := rep.Printer.T("header")
+
+	if cfg.Slack.BotToken == "" {
+		return channels.NewSlackChannel(cfg.Slack.WebhookURL).Send(header, rep)
+	}
+
+	routes := make(map[string]string)
+	for _, group := range cfg.DomainGroups {
+		for _, domain := range group.Domains {
+			if group.SlackChannel != "" {
+				routes[domain] = group.SlackChannel
+			}
+		}
+	}
+
+	channelOf := func(domain api.Domain) string {
+		if channelID, ok := routes[domain.Name]; ok {
+			return channelID
+		}
+		return cfg.Slack.Channel
+	}
+
+	var channelIDs []string
+	seen := make(map[string]bool)
+	for _, domain := range rep.Domains() {
+		if channelID := channelOf(domain); !seen[channelID] {
+			seen[channelID] = true
+			channelIDs = append(channelIDs, channelID)
+		}
+	}
+
+	for _, channelID := range channelIDs {
+		part := rep.Filter(func(domain api.Domain) bool {
+			return channelOf(domain) == channelID
+		})
+
+		err := channels.NewSlackBotChannel(cfg.Slack.BotToken, channelID, cfg.Slack.Thread).Send(header, part)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// logResults writes every checked domain to syslog and the systemd journal. Like alerts,
+// results are logged on every run regardless of SEND_ON_SUCCESS.
+func logResults(cfg config.Config, rep report.Report) {
+	if cfg.Syslog.Enabled {
+		syslog := channels.NewSyslogChannel(cfg.Syslog.Network, cfg.Syslog.Address, cfg.Syslog.Facility, cfg.Syslog.AppName, cfg.Syslog.CAFile)
+		if err := syslog.Send(rep.AllDomains()); err != nil {
+			fmt.Println(err)
+			panic(err)
+		}
+	}
+
+	if cfg.Journald.Enabled {
+		journald := channels.NewJournaldChannel(cfg.Syslog.AppName)
+		if !journald.Available() {
+			return
+		}
+		if err := journald.Send(rep.AllDomains()); err != nil {
+			fmt.Println(err)
+			panic(err)
+		}
+	}
+}
+
+// publishEvents publishes the result of every checked domain to the enabled message brokers on every run.
+// Broker errors are only logged, so that an unreachable broker does not stop the notifications.
+func publishEvents(cfg config.Config, rep report.Report) {
+	if !cfg.MQTT.Enabled && !cfg.NATS.Enabled && !cfg.AMQP.Enabled {
+		return
+	}
+
+	events := channels.NewDomainEvents(rep.AllDomains(), cfg.GroupTitle)
+
+	if cfg.MQTT.Enabled {
+		mqtt := channels.NewMQTTChannel(cfg.MQTT.BrokerURL, cfg.MQTT.ClientID, cfg.MQTT.Username, cfg.MQTT.Password, cfg.MQTT.TopicPrefix, cfg.MQTT.QoS, cfg.MQTT.Retain)
+		if err := mqtt.Publish(events); err != nil {
+			fmt.Println(err)
+		}
+	}
+
+	if cfg.NATS.Enabled {
+		if err := channels.NewNATSChannel(cfg.NATS.URL, cfg.NATS.CredsFile, cfg.NATS.SubjectPrefix).Publish(events); err != nil {
+			fmt.Println(err)
+		}
+	}
+
+	if cfg.AMQP.Enabled {
+		if err := channels.NewAMQPChannel(cfg.AMQP.URL, cfg.AMQP.Exchange).Publish(events); err != nil {
+			fmt.Println(err)
+		}
+	}
+}
+
+// syncAlerts opens and resolves per-domain alerts. Unlike messages they are updated on every run,
+// so that renewed domains are resolved even when successful runs are not reported.
+func syncAlerts(cfg config.Config, rep report.Report) {
+	bitrix24Tasks := cfg.Bitrix24.Enabled && cfg.Bitrix24.Tasks
+	if !cfg.PagerDuty.Enabled && !cfg.Opsgenie.Enabled && !cfg.Issues.Enabled && !bitrix24Tasks {
+		return
+	}
+
+	alerts, err := state.Load(cfg.StateFile)
+	if err != nil {
+		fmt.Println(err)
+		panic(err)
+	}
+
+	if cfg.PagerDuty.Enabled {
+		err = channels.NewPagerDutyChannel(cfg.PagerDuty.EventsURL, cfg.PagerDuty.RoutingKey).Sync(rep.AllDomains(), alerts)
+	}
+
+	if err == nil && cfg.Opsgenie.Enabled {
+		err = channels.NewOpsgenieChannel(cfg.Opsgenie.APIURL, cfg.Opsgenie.APIKey, cfg.LanguageFor("opsgenie")).Sync(rep.AllDomains(), cfg.GroupTitle, alerts)
+	}
+
+	if err == nil && cfg.Issues.Enabled {
+		var issues *channels.IssuesChannel
+		issues, err = channels.NewIssuesChannel(cfg.Issues.Tracker, cfg.Issues.URL, cfg.Issues.Username, cfg.Issues.Token,
+			cfg.Issues.Project, cfg.Issues.IssueType, cfg.Issues.Labels, cfg.LanguageFor("issues"))
+		if err == nil {
+			err = issues.Sync(rep.AllDomains(), cfg.Owner, alerts)
+		}
+	}
+
+	if err == nil && bitrix24Tasks {
+		responsible := func(string) string { return cfg.Bitrix24.ResponsibleID }
+		err = channels.NewBitrix24TasksChannel(cfg.Bitrix24.WebhookURL, cfg.LanguageFor("bitrix24")).Sync(rep.AllDomains(), responsible, alerts)
+	}
+
+	// Save alerts changed before a failure so they are not sent again.
+	if saveErr := alerts.Save(); saveErr != nil {
+		fmt.Println(saveErr)
+		panic(saveErr)
+	}
+
+	if err != nil {
+		fmt.Println(err)
+		panic(err)
+	}
 }
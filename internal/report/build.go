@@ -0,0 +1,61 @@
+package report
+
+import (
+	"github.com/Kravets1996/kz-domain-monitor/internal/api"
+	"github.com/Kravets1996/kz-domain-monitor/internal/config"
+	"sort"
+)
+
+// New builds a report from checked domains. With the "group" sort order the domains
+// are arranged by the configured groups, otherwise they form a single untitled group.
+func New(domains []api.Domain, groups []config.DomainGroup, sortOrder string) Report {
+	if sortOrder == "group" && len(groups) > 0 {
+		return buildGrouped(domains, groups)
+	}
+
+	sorted := make([]api.Domain, len(domains))
+	copy(sorted, domains)
+	sortDomains(sorted, sortOrder)
+
+	return Report{Groups: []Group{{Domains: sorted}}}
+}
+
+func buildGrouped(domains []api.Domain, groups []config.DomainGroup) Report {
+	domainMap := make(map[string]api.Domain, len(domains))
+	for _, d := range domains {
+		domainMap[d.Name] = d
+	}
+
+	var rep Report
+	for _, group := range groups {
+		var groupDomains []api.Domain
+		for _, name := range group.Domains {
+			if d, ok := domainMap[name]; ok {
+				groupDomains = append(groupDomains, d)
+			}
+		}
+		if len(groupDomains) > 0 {
+			rep.Groups = append(rep.Groups, Group{Title: group.Title, Domains: groupDomains})
+		}
+	}
+	return rep
+}
+
+func sortDomains(domains []api.Domain, sortOrder string) {
+	switch sortOrder {
+	case "expiration":
+		sort.SliceStable(domains, func(i, j int) bool {
+			if domains[i].ExpirationDate == nil {
+				return true
+			}
+			if domains[j].ExpirationDate == nil {
+				return false
+			}
+			return domains[i].ExpirationDate.Before(*domains[j].ExpirationDate)
+		})
+	case "alphabet":
+		sort.SliceStable(domains, func(i, j int) bool {
+			return domains[i].Name < domains[j].Name
+		})
+	}
+}
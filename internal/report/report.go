@@ -0,0 +1,181 @@
+package report
+
+import (
+	"github.com/Kravets1996/kz-domain-monitor/internal/api"
+	"github.com/Kravets1996/kz-domain-monitor/internal/i18n"
+	"github.com/Kravets1996/kz-domain-monitor/internal/templates"
+)
+
+// Report is the structured result of a check run passed to notification channels.
+type Report struct {
+	Groups   []Group
+	HasError bool
+	// All holds every checked domain, including the ones left out of Groups.
+	All []api.Domain
+	// Printer is the language the report is rendered in. The zero value uses LANGUAGE.
+	Printer i18n.Printer
+	// Template replaces the default layout of the channel. Nil keeps the default layout.
+	Template *templates.Template
+	// Notices are messages about the whole run, e.g. a failed import, shown after the header.
+	Notices []Notice
+}
+
+// Notice is a message about the whole run, rendered in the report language.
+type Notice struct {
+	Key  string
+	Args []any
+}
+
+// Stats holds domain counts of a check run.
+type Stats struct {
+	Total    int
+	Ok       int
+	Expiring int // close to expiration
+	Expired  int // expired or available for registration
+	Errors   int // failed checks
+}
+
+// Problems returns the number of domains that are not ok.
+func (s Stats) Problems() int {
+	return s.Total - s.Ok
+}
+
+// Group is a titled block of domains. Domains outside of any group have an empty title.
+type Group struct {
+	Title string
+	// TitleKey is the message key of the title. When set, the title is rendered in the report language.
+	TitleKey string
+	Domains  []api.Domain
+}
+
+// IsEmpty reports whether the report contains no domains and no notices.
+func (r Report) IsEmpty() bool {
+	if len(r.Notices) > 0 {
+		return false
+	}
+	for _, group := range r.Groups {
+		if len(group.Domains) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Domains returns all domains of the report in group order.
+func (r Report) Domains() []api.Domain {
+	var domains []api.Domain
+	for _, group := range r.Groups {
+		domains = append(domains, group.Domains...)
+	}
+	return domains
+}
+
+// AllDomains returns every checked domain, falling back to the report domains.
+func (r Report) AllDomains() []api.Domain {
+	if r.All != nil {
+		return r.All
+	}
+	return r.Domains()
+}
+
+// Stats counts all checked domains by severity.
+func (r Report) Stats() Stats {
+	var stats Stats
+	for _, domain := range r.AllDomains() {
+		stats.Total++
+		switch domain.GetSeverity() {
+		case api.SeverityOk:
+			stats.Ok++
+		case api.SeverityWarning:
+			stats.Expiring++
+		case api.SeverityCritical:
+			stats.Expired++
+		case api.SeverityError:
+			stats.Errors++
+		}
+	}
+	return stats
+}
+
+// NoticeLines returns the notices in the report language, e.g. "⚠️ Не удалось загрузить домены аккаунта ps.kz: ...".
+func (r Report) NoticeLines() []string {
+	lines := make([]string, 0, len(r.Notices))
+	for _, notice := range r.Notices {
+		lines = append(lines, "⚠️ "+r.Printer.T(notice.Key, notice.Args...))
+	}
+	return lines
+}
+
+// Lines returns the plain-text representation of the report: the notices, group titles,
+// one line per domain and an empty line between groups.
+func (r Report) Lines() []string {
+	lines := r.NoticeLines()
+	for _, group := range r.Groups {
+		if len(group.Domains) == 0 {
+			continue
+		}
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		if group.Title != "" {
+			lines = append(lines, group.Title+":")
+		}
+		for _, domain := range group.Domains {
+			lines = append(lines, domain.Message(r.Printer))
+		}
+	}
+	return lines
+}
+
+// In returns a copy of the report rendered in lang. An empty lang keeps the default language.
+func (r Report) In(lang string) Report {
+	r.Printer = i18n.New(lang)
+
+	groups := make([]Group, len(r.Groups))
+	for i, group := range r.Groups {
+		if group.TitleKey != "" {
+			group.Title = r.Printer.T(group.TitleKey)
+		}
+		groups[i] = group
+	}
+	r.Groups = groups
+
+	return r
+}
+
+// Severity returns the most severe state among the group domains.
+func (g Group) Severity() api.Severity {
+	severity := api.SeverityOk
+	for _, domain := range g.Domains {
+		severity = max(severity, domain.GetSeverity())
+	}
+	return severity
+}
+
+// Severity returns the most severe state among the report domains.
+func (r Report) Severity() api.Severity {
+	severity := api.SeverityOk
+	for _, group := range r.Groups {
+		severity = max(severity, group.Severity())
+	}
+	return severity
+}
+
+// Filter returns a copy of the report with only the domains matching keep.
+// Groups left without domains are dropped.
+func (r Report) Filter(keep func(api.Domain) bool) Report {
+	filtered := Report{Printer: r.Printer, Template: r.Template, Notices: r.Notices}
+	for _, group := range r.Groups {
+		var domains []api.Domain
+		for _, domain := range group.Domains {
+			if keep(domain) {
+				domains = append(domains, domain)
+				filtered.HasError = filtered.HasError || !domain.IsOk()
+			}
+		}
+		if len(domains) > 0 {
+			filtered.Groups = append(filtered.Groups, Group{Title: group.Title, TitleKey: group.TitleKey, Domains: domains})
+		}
+	}
+	return filtered
+}
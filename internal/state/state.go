@@ -0,0 +1,88 @@
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"sort"
+	"time"
+)
+
+// Alert is an open alert or ticket created for a domain by an integration.
+type Alert struct {
+	// ID is the identifier of the alert in the external system, if it has one.
+	ID        string    `json:"id,omitempty"`
+	Severity  string    `json:"severity,omitempty"`
+	DaysLeft  int64     `json:"daysLeft,omitempty"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// State keeps open alerts between runs, grouped by integration and domain name.
+type State struct {
+	path   string
+	Alerts map[string]map[string]Alert `json:"alerts"`
+}
+
+// Load reads the state file. A missing file results in an empty state.
+func Load(path string) (*State, error) {
+	s := &State{path: path, Alerts: make(map[string]map[string]Alert)}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, err
+	}
+	if s.Alerts == nil {
+		s.Alerts = make(map[string]map[string]Alert)
+	}
+
+	return s, nil
+}
+
+// Save writes the state back to the file it was loaded from.
+func (s *State) Save() error {
+	data, err := json.MarshalIndent(s, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(s.path, append(data, '\n'), 0644)
+}
+
+// Get returns the open alert of an integration for a domain.
+func (s *State) Get(integration, domain string) (Alert, bool) {
+	alert, ok := s.Alerts[integration][domain]
+	return alert, ok
+}
+
+// Set stores an open alert of an integration for a domain.
+func (s *State) Set(integration, domain string, alert Alert) {
+	if s.Alerts[integration] == nil {
+		s.Alerts[integration] = make(map[string]Alert)
+	}
+	s.Alerts[integration][domain] = alert
+}
+
+// Delete removes the alert of an integration for a domain.
+func (s *State) Delete(integration, domain string) {
+	delete(s.Alerts[integration], domain)
+	if len(s.Alerts[integration]) == 0 {
+		delete(s.Alerts, integration)
+	}
+}
+
+// Domains returns the sorted names of domains with open alerts of an integration.
+func (s *State) Domains(integration string) []string {
+	domains := make([]string, 0, len(s.Alerts[integration]))
+	for domain := range s.Alerts[integration] {
+		domains = append(domains, domain)
+	}
+	sort.Strings(domains)
+	return domains
+}
@@ -0,0 +1,36 @@
+package state
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestState_SaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+
+	s, err := Load(path)
+	if err != nil {
+		t.Fatal("missing state file should result in an empty state:", err)
+	}
+
+	s.Set("pagerduty", "example.kz", Alert{Severity: "warning", DaysLeft: 10, UpdatedAt: time.Now()})
+	s.Set("pagerduty", "egov.kz", Alert{Severity: "critical"})
+	s.Delete("pagerduty", "egov.kz")
+
+	if err := s.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if domains := loaded.Domains("pagerduty"); len(domains) != 1 || domains[0] != "example.kz" {
+		t.Errorf("unexpected domains: %v", domains)
+	}
+	if alert, ok := loaded.Get("pagerduty", "example.kz"); !ok || alert.DaysLeft != 10 {
+		t.Errorf("unexpected alert: %+v", alert)
+	}
+}
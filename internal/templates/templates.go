@@ -0,0 +1,291 @@
+// Package templates renders notifications with user-defined text/template or html/template files.
+//
+// A template file defines any of the "header", "group", "domain" and "footer" templates:
+//
+//	{{define "header"}}{{.Header}}: {{.Stats.Problems}} из {{n "of_domains" .Stats.Total}}{{end}}
+//	{{define "domain"}}{{.Icon}} {{.Name}} — {{.Status}}{{end}}
+//
+// Templates that are not defined fall back to the default layout.
+package templates
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/Kravets1996/kz-domain-monitor/internal/i18n"
+	htmlTemplate "html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"text/template/parse"
+	"time"
+)
+
+// Names of the templates a file can define.
+const (
+	HeaderTemplate = "header"
+	GroupTemplate  = "group"
+	DomainTemplate = "domain"
+	FooterTemplate = "footer"
+)
+
+var names = []string{HeaderTemplate, GroupTemplate, DomainTemplate, FooterTemplate}
+
+// textDefaults and htmlDefaults are used for the templates missing in a file.
+var textDefaults = map[string]string{
+	HeaderTemplate: `{{.Header}}`,
+	GroupTemplate:  `{{if .Title}}{{.Title}}:{{end}}`,
+	DomainTemplate: `{{.Message}}`,
+	FooterTemplate: ``,
+}
+
+var htmlDefaults = map[string]string{
+	HeaderTemplate: `{{.Header}}`,
+	GroupTemplate:  `{{if .Title}}<b>{{.Title}}:</b>{{end}}`,
+	DomainTemplate: `{{.Message}}`,
+	FooterTemplate: ``,
+}
+
+// Report is passed to the header and footer templates.
+type Report struct {
+	Header string
+	// Notices are messages about the whole run, e.g. a failed import. Render puts them before the groups.
+	Notices []string
+	Stats   Stats
+	Groups  []Group
+}
+
+// Stats holds domain counts of a check run.
+type Stats struct {
+	Total    int
+	Ok       int
+	Expiring int
+	Expired  int
+	Errors   int
+	Problems int
+}
+
+// Group is passed to the group template.
+type Group struct {
+	Title    string
+	Severity string
+	Domains  []Domain
+}
+
+// Domain is passed to the domain template.
+type Domain struct {
+	Name           string
+	Group          string
+	IsAvailable    bool
+	ExpirationDate *time.Time
+	Registrar      string
+	// DaysLeft is zero when the expiration date is unknown.
+	DaysLeft int
+	// Severity is ok, warning, error or critical.
+	Severity string
+	Icon     string
+	// Status is the domain state without its name, e.g. "⚠️ 3 дня".
+	Status string
+	// Message is the default line, e.g. "⚠️ 3 дня - example.kz".
+	Message string
+	Error   string
+}
+
+// Output is a rendered report.
+type Output struct {
+	Header string
+	// Body holds the notices, a block per group and the footer. Empty blocks are left out.
+	Body []string
+}
+
+// Blocks returns the header followed by the body blocks.
+func (o Output) Blocks() []string {
+	if o.Header == "" {
+		return o.Body
+	}
+	return append([]string{o.Header}, o.Body...)
+}
+
+type executor interface {
+	ExecuteTemplate(w io.Writer, name string, data any) error
+}
+
+// Template is a parsed template file.
+type Template struct {
+	path string
+	html bool
+	tmpl executor
+}
+
+// Load parses the template file at path with the functions of the printer. HTML templates use
+// html/template, which escapes the values. The templates are executed with sample data, so that
+// unknown fields are reported at startup rather than when a notification is sent.
+func Load(path string, html bool, p i18n.Printer) (*Template, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	name := filepath.Base(path)
+	var t *Template
+
+	if html {
+		tmpl, err := htmlTemplate.New(name).Funcs(htmlTemplate.FuncMap(p.TemplateFuncs())).Parse(string(data))
+		if err != nil {
+			return nil, err
+		}
+		defined := func(name string) bool { return tmpl.Lookup(name) != nil }
+		if err := checkDefined(name, tmpl.Tree, defined); err != nil {
+			return nil, err
+		}
+		if _, err := tmpl.New("defaults").Parse(missingDefaults(htmlDefaults, defined)); err != nil {
+			return nil, err
+		}
+		t = &Template{path: path, html: true, tmpl: tmpl}
+	} else {
+		tmpl, err := template.New(name).Funcs(p.TemplateFuncs()).Parse(string(data))
+		if err != nil {
+			return nil, err
+		}
+		defined := func(name string) bool { return tmpl.Lookup(name) != nil }
+		if err := checkDefined(name, tmpl.Tree, defined); err != nil {
+			return nil, err
+		}
+		if _, err := tmpl.New("defaults").Parse(missingDefaults(textDefaults, defined)); err != nil {
+			return nil, err
+		}
+		t = &Template{path: path, tmpl: tmpl}
+	}
+
+	if _, err := t.Render(sampleReport()); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// checkDefined makes sure the file defines at least one of the templates and has no text outside of them.
+func checkDefined(name string, root *parse.Tree, defined func(name string) bool) error {
+	if root != nil && root.Root != nil {
+		for _, node := range root.Root.Nodes {
+			if text, ok := node.(*parse.TextNode); !ok || strings.TrimSpace(string(text.Text)) != "" {
+				return fmt.Errorf("%s: text outside of {{define}} is not used, define %s", name, strings.Join(quote(names), ", "))
+			}
+		}
+	}
+
+	for _, n := range names {
+		if defined(n) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s: none of %s is defined", name, strings.Join(quote(names), ", "))
+}
+
+// missingDefaults returns the default definitions of the templates missing in the file.
+func missingDefaults(defaults map[string]string, defined func(name string) bool) string {
+	var src strings.Builder
+	for _, name := range names {
+		if !defined(name) {
+			src.WriteString(`{{define "` + name + `"}}` + defaults[name] + `{{end}}`)
+		}
+	}
+	return src.String()
+}
+
+func quote(values []string) []string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = `"` + value + `"`
+	}
+	return quoted
+}
+
+// Render executes the templates: the header, the group heading with one line per domain for every
+// group and the footer. The notices are added after the header as they are, escaped in HTML templates.
+func (t *Template) Render(rep Report) (Output, error) {
+	var out Output
+
+	header, err := t.execute(HeaderTemplate, rep)
+	if err != nil {
+		return Output{}, err
+	}
+	out.Header = header
+
+	if len(rep.Notices) > 0 {
+		notices := strings.Join(rep.Notices, "\n")
+		if t.html {
+			notices = htmlTemplate.HTMLEscapeString(notices)
+		}
+		out.Body = append(out.Body, notices)
+	}
+
+	for _, group := range rep.Groups {
+		if len(group.Domains) == 0 {
+			continue
+		}
+
+		var lines []string
+		heading, err := t.execute(GroupTemplate, group)
+		if err != nil {
+			return Output{}, err
+		}
+		if heading != "" {
+			lines = append(lines, heading)
+		}
+
+		for _, domain := range group.Domains {
+			line, err := t.execute(DomainTemplate, domain)
+			if err != nil {
+				return Output{}, err
+			}
+			if line != "" {
+				lines = append(lines, line)
+			}
+		}
+
+		if len(lines) > 0 {
+			out.Body = append(out.Body, strings.Join(lines, "\n"))
+		}
+	}
+
+	footer, err := t.execute(FooterTemplate, rep)
+	if err != nil {
+		return Output{}, err
+	}
+	if footer != "" {
+		out.Body = append(out.Body, footer)
+	}
+
+	return out, nil
+}
+
+// execute runs a template and trims the line breaks around {{define}} blocks.
+func (t *Template) execute(name string, data any) (string, error) {
+	var buf bytes.Buffer
+	if err := t.tmpl.ExecuteTemplate(&buf, name, data); err != nil {
+		return "", fmt.Errorf("%s: %w", filepath.Base(t.path), err)
+	}
+	return strings.Trim(buf.String(), "\r\n"), nil
+}
+
+// sampleReport covers every domain state for the startup check of the templates.
+func sampleReport() Report {
+	expiration := time.Now().AddDate(0, 0, 3)
+	domains := []Domain{
+		{Name: "example.kz", Group: "Sites", ExpirationDate: &expiration, Registrar: "PS Internet Company", DaysLeft: 3, Severity: "warning", Icon: "⚠️", Status: "⚠️ 3", Message: "⚠️ 3 - example.kz"},
+		{Name: "free.kz", Group: "Sites", IsAvailable: true, Severity: "critical", Icon: "❌", Status: "❌", Message: "❌ free.kz"},
+		{Name: "broken.kz", Severity: "error", Icon: "❗️", Status: "❗️ error", Message: "❗️ error", Error: "error"},
+	}
+
+	return Report{
+		Header:  "kz-domain-monitor",
+		Notices: []string{"⚠️ notice"},
+		Stats:   Stats{Total: 3, Expiring: 1, Expired: 1, Errors: 1, Problems: 3},
+		Groups: []Group{
+			{Title: "Sites", Severity: "critical", Domains: domains[:2]},
+			{Severity: "error", Domains: domains[2:]},
+		},
+	}
+}
@@ -0,0 +1,98 @@
+package templates
+
+import (
+	"github.com/Kravets1996/kz-domain-monitor/internal/i18n"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeTemplate(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "report.tmpl")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestTemplate_Render(t *testing.T) {
+	path := writeTemplate(t, `
+{{define "header"}}{{.Header}}: {{.Stats.Problems}} из {{n "of_domains" .Stats.Total}}{{end}}
+{{define "domain"}}
+{{.Name}} [{{.Severity}}] {{.DaysLeft}}
+{{end}}
+{{define "footer"}}--{{end}}
+`)
+
+	tmpl, err := Load(path, false, i18n.New("ru"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := tmpl.Render(Report{
+		Header: "Проверка",
+		Stats:  Stats{Total: 2, Problems: 1},
+		Groups: []Group{
+			{Title: "Сайты", Domains: []Domain{{Name: "example.kz", Severity: "ok", DaysLeft: 90}, {Name: "expiring.kz", Severity: "warning", DaysLeft: 3}}},
+			{Title: "Пусто"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"Проверка: 1 из 2 доменов",
+		"Сайты:\nexample.kz [ok] 90\nexpiring.kz [warning] 3",
+		"--",
+	}
+	if strings.Join(out.Blocks(), "|") != strings.Join(expected, "|") {
+		t.Errorf("unexpected blocks: %q", out.Blocks())
+	}
+}
+
+func TestTemplate_RenderHTMLEscapesValues(t *testing.T) {
+	path := writeTemplate(t, `{{define "domain"}}<code>{{.Name}}</code>{{end}}`)
+
+	tmpl, err := Load(path, true, i18n.New("ru"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := tmpl.Render(Report{Header: "A & B", Notices: []string{"⚠️ <timeout>"}, Groups: []Group{{Title: "<prod>", Domains: []Domain{{Name: "<b>.kz"}}}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if out.Header != "A &amp; B" {
+		t.Errorf("unexpected header: %q", out.Header)
+	}
+	if out.Body[0] != "⚠️ &lt;timeout&gt;" {
+		t.Errorf("unexpected notices: %q", out.Body[0])
+	}
+	if out.Body[1] != "<b>&lt;prod&gt;:</b>\n<code>&lt;b&gt;.kz</code>" {
+		t.Errorf("unexpected group: %q", out.Body[1])
+	}
+}
+
+func TestLoad_Errors(t *testing.T) {
+	tests := map[string]struct {
+		content  string
+		expected string
+	}{
+		"unknown field":   {`{{define "domain"}}{{.Days}}{{end}}`, `can't evaluate field Days`},
+		"syntax":          {`{{define "domain"}}{{.Name}{{end}}`, `report.tmpl:1`},
+		"nothing defined": {`{{define "row"}}{{.Name}}{{end}}`, `none of "header", "group", "domain", "footer" is defined`},
+		"text outside":    {`{{.Name}}`, `text outside of {{define}} is not used`},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Load(writeTemplate(t, test.content), false, i18n.New("ru"))
+			if err == nil || !strings.Contains(err.Error(), test.expected) {
+				t.Errorf("expected error containing %q, got %v", test.expected, err)
+			}
+		})
+	}
+}
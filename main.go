@@ -1,16 +1,25 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"kz-domain-monitor/internal/api"
-	"kz-domain-monitor/internal/config"
-	"kz-domain-monitor/internal/notification"
+	"github.com/Kravets1996/kz-domain-monitor/internal/api"
+	"github.com/Kravets1996/kz-domain-monitor/internal/bot"
+	"github.com/Kravets1996/kz-domain-monitor/internal/config"
+	"github.com/Kravets1996/kz-domain-monitor/internal/notification"
+	"github.com/Kravets1996/kz-domain-monitor/internal/report"
+	"github.com/Kravets1996/kz-domain-monitor/pkg/kzdomain"
 	"log"
 	"net/http"
 	"os"
+	"os/signal"
 	"runtime"
-	"sort"
-	"time"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+	_ "time/tzdata"
 
 	"github.com/fynelabs/selfupdate"
 	"github.com/joho/godotenv"
@@ -42,41 +51,67 @@ func main() {
 	config.Init()
 	cfg := config.GetConfig()
 
-	var domains []api.Domain
-	hasError := false
+	if len(os.Args) > 1 && os.Args[1] == "bot" {
+		runBot(cfg)
+		return
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 
-	for i, domainName := range cfg.DomainList {
-		domain := api.GetDomainInfo(domainName)
+	account, accountErr := fetchAccount(ctx, cfg)
+	imported, importErr := importDomains(ctx, cfg, account)
+	balanceNotice := checkRenewals(cfg, account, accountErr)
+	checked, err := api.CheckDomains(ctx, cfg, append(slices.Clone(cfg.DomainList), imported...))
 
-		log.Println(domain.GetMessage())
+	// Once the check is done, a second interrupt stops sending notifications.
+	stop()
+
+	if err != nil {
+		log.Printf("Check aborted: %v. Not checked: %s", err, strings.Join(notChecked(checked), ", "))
+	}
 
+	isImported := make(map[string]bool, len(imported))
+	for _, name := range imported {
+		isImported[name] = true
+	}
+
+	var domains, importedDomains []api.Domain
+	hasError := importErr != nil || balanceNotice != nil
+
+	for _, domain := range checked {
 		hasError = hasError || !domain.IsOk()
 
-		if domain.ShouldSend() {
+		// Imported domains are reported regardless of SEND_ONLY_ERRORS: the group lists every domain missing from the configuration.
+		if isImported[domain.Name] {
+			importedDomains = append(importedDomains, domain)
+		} else if domain.ShouldSend() {
 			domains = append(domains, domain)
 		}
+	}
 
-		if i < len(cfg.DomainList)-1 {
-			time.Sleep(cfg.RequestDelay)
-		}
+	rep := report.New(domains, cfg.DomainGroups, cfg.SortOrder)
+	if len(importedDomains) > 0 {
+		importedReport := report.New(importedDomains, nil, cfg.SortOrder)
+		rep.Groups = append(rep.Groups, report.Group{TitleKey: "import.group", Domains: importedReport.Domains()})
 	}
+	rep.HasError = hasError
+	rep.All = checked
 
-	sortDomains(domains, cfg.SortOrder)
+	if importErr != nil {
+		rep.Notices = append(rep.Notices, report.Notice{Key: "import.failed", Args: []any{importErr.Error()}})
 
-	var messages []string
-	if cfg.SortOrder == "group" && len(cfg.DomainGroups) > 0 {
-		messages = buildGroupedMessages(domains, cfg.DomainGroups)
-	} else {
-		for _, domain := range domains {
-			messages = append(messages, domain.GetMessage())
+		// Keep the alerts of domains imported by earlier runs until the account can be loaded again.
+		notImported, err := api.NotImported(cfg, importErr)
+		if err != nil {
+			log.Printf("Failed to load the domains imported by earlier runs: %v", err)
 		}
+		rep.All = append(rep.All, notImported...)
 	}
-
-	if !hasError && !cfg.SendSuccess {
-		return
+	if balanceNotice != nil {
+		rep.Notices = append(rep.Notices, *balanceNotice)
 	}
 
-	notification.SendNotification(messages, hasError)
+	notification.SendNotification(rep)
 
 	if hasError {
 		os.Exit(1)
@@ -85,51 +120,96 @@ func main() {
 	os.Exit(0)
 }
 
-func buildGroupedMessages(domains []api.Domain, groups []config.DomainGroup) []string {
-	domainMap := make(map[string]api.Domain, len(domains))
-	for _, d := range domains {
-		domainMap[d.Name] = d
+// fetchAccount fetches the ps.kz account with the balance once per run when PS_CHECK_BALANCE is enabled.
+// It returns nil when the balance check is disabled.
+func fetchAccount(ctx context.Context, cfg config.Config) (*kzdomain.Account, error) {
+	if !cfg.PSCheckBalance {
+		return nil, nil
+	}
+
+	account, err := api.FetchAccount(ctx, cfg)
+	if err != nil {
+		log.Printf("Failed to load the ps.kz account: %v", err)
+		return nil, err
+	}
+
+	return &account, nil
+}
+
+// importDomains returns the ps.kz account domains missing from the configuration when PS_IMPORT_DOMAINS is enabled.
+// The domains of the fetched account are used when there is one; otherwise only the names are queried,
+// which works for tokens without access to the balance.
+func importDomains(ctx context.Context, cfg config.Config, account *kzdomain.Account) ([]string, error) {
+	if !cfg.Import.Enabled {
+		return nil, nil
 	}
 
-	var messages []string
-	for index, group := range groups {
-		var groupMessages []string
-		for _, name := range group.Domains {
-			if d, ok := domainMap[name]; ok {
-				groupMessages = append(groupMessages, d.GetMessage())
-			}
+	var imported []string
+	if account != nil {
+		imported = api.ImportAccountDomains(*account, cfg)
+	} else {
+		var err error
+		imported, err = api.ImportDomains(ctx, cfg)
+		if err != nil {
+			log.Printf("Failed to import domains from ps.kz: %v", err)
+			return nil, err
 		}
-		if len(groupMessages) > 0 {
-			if group.Title != "" {
-				messages = append(messages, group.Title+":")
-			}
-			messages = append(messages, groupMessages...)
-
-			if index < len(groups)-1 {
-				messages = append(messages, "")
-			}
+	}
+
+	if len(imported) > 0 {
+		log.Printf("Domains in the ps.kz account missing from the configuration: %s", strings.Join(imported, ", "))
+	}
+
+	return imported, nil
+}
+
+// checkRenewals compares the ps.kz account balance with the auto-renewals due when PS_CHECK_BALANCE is enabled.
+// It returns a notice when the account could not be loaded or the balance does not cover the renewals.
+func checkRenewals(cfg config.Config, account *kzdomain.Account, accountErr error) *report.Notice {
+	if !cfg.PSCheckBalance {
+		return nil
+	}
+	if accountErr != nil {
+		return &report.Notice{Key: "balance.failed", Args: []any{accountErr.Error()}}
+	}
+
+	renewals := api.Renewals(*account, cfg)
+
+	balance := strconv.FormatFloat(renewals.Balance, 'f', -1, 64)
+	total := strconv.FormatFloat(renewals.Total, 'f', -1, 64)
+	due := make([]string, 0, len(renewals.Due))
+	for _, domain := range renewals.Due {
+		due = append(due, domain.Name)
+	}
+	log.Printf("ps.kz balance: %s, auto-renewals due for %s: %s", balance, total, strings.Join(due, ", "))
+
+	if renewals.IsCovered() {
+		return nil
+	}
+
+	return &report.Notice{Key: "balance.low", Args: []any{balance, total}}
+}
+
+func notChecked(domains []api.Domain) []string {
+	var names []string
+	for _, domain := range domains {
+		if errors.Is(domain.Error, api.ErrNotChecked) {
+			names = append(names, domain.Name)
 		}
 	}
-	return messages
+	return names
 }
 
-func sortDomains(domains []api.Domain, sortOrder string) {
-	switch sortOrder {
-	case "expiration":
-		sort.SliceStable(domains, func(i, j int) bool {
-			if domains[i].ExpirationDate == nil {
-				return true
-			}
-			if domains[j].ExpirationDate == nil {
-				return false
-			}
-			return domains[i].ExpirationDate.Before(*domains[j].ExpirationDate)
-		})
-	case "alphabet":
-		sort.SliceStable(domains, func(i, j int) bool {
-			return domains[i].Name < domains[j].Name
-		})
+func runBot(cfg config.Config) {
+	telegramBot, err := bot.New(cfg)
+	if err != nil {
+		log.Fatalf("Failed to start Telegram bot: %v", err)
 	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	telegramBot.Run(ctx)
 }
 
 func printVersion() {
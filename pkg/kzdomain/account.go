@@ -0,0 +1,57 @@
+package kzdomain
+
+import "time"
+
+// Account is the state of a registrar account.
+type Account struct {
+	Balance float64
+	Domains []AccountDomain
+}
+
+// AccountDomain is a domain registered in the account.
+type AccountDomain struct {
+	Name string
+	// ExpirationDate is nil when the registrar did not report it.
+	ExpirationDate *time.Time
+	// AutoRenew reports whether the domain is renewed from the balance on expiration.
+	AutoRenew bool
+	// RenewalPrice is the price of a renewal in the account currency.
+	RenewalPrice float64
+}
+
+// Renewals are the automatic renewals due within the policy threshold.
+type Renewals struct {
+	Balance float64
+	// Due are the domains renewed automatically within the threshold.
+	Due []AccountDomain
+	// Total is the price of the due renewals.
+	Total float64
+}
+
+// IsCovered reports whether the balance covers the due renewals.
+func (r Renewals) IsCovered() bool {
+	return len(r.Due) == 0 || r.Total <= r.Balance
+}
+
+// Renewals returns the auto-renewed domains that are close to expiration by their policy, or already expired.
+func (a Account) Renewals(policy func(name string) Policy) Renewals {
+	renewals := Renewals{Balance: a.Balance}
+
+	for _, domain := range a.Domains {
+		if !domain.AutoRenew || domain.ExpirationDate == nil {
+			continue
+		}
+
+		result := Result{Name: domain.Name, ExpirationDate: domain.ExpirationDate}
+		if policy != nil {
+			result.Policy = policy(domain.Name)
+		}
+
+		if result.IsCloseToExpire() {
+			renewals.Due = append(renewals.Due, domain)
+			renewals.Total += domain.RenewalPrice
+		}
+	}
+
+	return renewals
+}
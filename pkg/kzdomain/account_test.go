@@ -0,0 +1,43 @@
+package kzdomain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAccount_Renewals(t *testing.T) {
+	days := func(n int) *time.Time {
+		date := time.Now().AddDate(0, 0, n)
+		return &date
+	}
+
+	account := Account{
+		Balance: 7000,
+		Domains: []AccountDomain{
+			{Name: "soon.kz", ExpirationDate: days(3), AutoRenew: true, RenewalPrice: 5000},
+			{Name: "expired.kz", ExpirationDate: days(-1), AutoRenew: true, RenewalPrice: 5000},
+			{Name: "manual.kz", ExpirationDate: days(3), RenewalPrice: 5000},
+			{Name: "later.kz", ExpirationDate: days(40), AutoRenew: true, RenewalPrice: 5000},
+			{Name: "unknown.kz", AutoRenew: true, RenewalPrice: 5000},
+		},
+	}
+
+	renewals := account.Renewals(func(name string) Policy {
+		if name == "later.kz" {
+			return Policy{DaysToExpire: 30}
+		}
+		return Policy{DaysToExpire: 15}
+	})
+
+	if len(renewals.Due) != 2 || renewals.Due[0].Name != "soon.kz" || renewals.Due[1].Name != "expired.kz" {
+		t.Errorf("unexpected due renewals: %+v", renewals.Due)
+	}
+	if renewals.Total != 10000 || renewals.IsCovered() {
+		t.Errorf("balance 7000 should not cover renewals for %v", renewals.Total)
+	}
+
+	renewals.Balance = 10000
+	if !renewals.IsCovered() {
+		t.Error("balance 10000 should cover renewals for 10000")
+	}
+}
@@ -0,0 +1,135 @@
+package kzdomain
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Checker looks up domains with a provider.
+type Checker struct {
+	provider Provider
+	delay    time.Duration
+	policy   func(name string) Policy
+	onResult func(Result)
+}
+
+// Option configures a Checker.
+type Option func(*Checker)
+
+// WithDelay pauses between requests, e.g. to stay within the rate limit of the provider.
+func WithDelay(delay time.Duration) Option {
+	return func(c *Checker) {
+		c.delay = delay
+	}
+}
+
+// WithPolicy sets the policy of every domain. Without it results use the zero Policy.
+func WithPolicy(policy func(name string) Policy) Option {
+	return func(c *Checker) {
+		c.policy = policy
+	}
+}
+
+// OnResult calls fn with every result as soon as it is received.
+func OnResult(fn func(Result)) Option {
+	return func(c *Checker) {
+		c.onResult = fn
+	}
+}
+
+// New returns a checker that looks up domains with provider.
+func New(provider Provider, options ...Option) *Checker {
+	c := &Checker{provider: provider}
+	for _, option := range options {
+		option(c)
+	}
+	return c
+}
+
+// Check looks up the domains one by one, or in batches when the provider is a BatchProvider. A failed lookup
+// is reported in Result.Error and does not stop the check. The delay is applied between requests. When ctx
+// is done, Check returns the results received so far and the context error.
+func (c *Checker) Check(ctx context.Context, domains []string) ([]Result, error) {
+	results := make([]Result, 0, len(domains))
+	batches := c.batches(domains)
+
+	for i, batch := range batches {
+		batchResults := c.lookup(ctx, batch)
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return results, ctxErr
+		}
+
+		for _, result := range batchResults {
+			if c.policy != nil {
+				result.Policy = c.policy(result.Name)
+			}
+			if c.onResult != nil {
+				c.onResult(result)
+			}
+			results = append(results, result)
+		}
+
+		if i < len(batches)-1 {
+			if err := sleep(ctx, c.delay); err != nil {
+				return results, err
+			}
+		}
+	}
+
+	return results, nil
+}
+
+// batches splits domains into groups looked up in one request.
+func (c *Checker) batches(domains []string) [][]string {
+	size := 1
+	if provider, ok := c.provider.(BatchProvider); ok && provider.MaxBatchSize() > 1 {
+		size = provider.MaxBatchSize()
+	}
+
+	var batches [][]string
+	for start := 0; start < len(domains); start += size {
+		batches = append(batches, domains[start:min(start+size, len(domains))])
+	}
+	return batches
+}
+
+// lookup returns a result for every domain of the batch.
+func (c *Checker) lookup(ctx context.Context, batch []string) []Result {
+	if provider, ok := c.provider.(BatchProvider); ok && len(batch) > 1 {
+		results, err := provider.LookupBatch(ctx, batch)
+		if err == nil && len(results) != len(batch) {
+			err = fmt.Errorf("expected %d results, got %d", len(batch), len(results))
+		}
+		if err != nil {
+			results = make([]Result, 0, len(batch))
+			for _, name := range batch {
+				results = append(results, Result{Name: name, Error: err})
+			}
+		}
+		return results
+	}
+
+	result, err := c.provider.Lookup(ctx, batch[0])
+	if err != nil {
+		result = Result{Name: batch[0], Error: err}
+	}
+	return []Result{result}
+}
+
+// sleep pauses for d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
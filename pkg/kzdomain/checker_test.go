@@ -0,0 +1,142 @@
+package kzdomain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// providerFunc adapts a function to the Provider interface.
+type providerFunc func(ctx context.Context, domainName string) (Result, error)
+
+func (f providerFunc) Lookup(ctx context.Context, domainName string) (Result, error) {
+	return f(ctx, domainName)
+}
+
+// batchProvider looks up domains in batches of two.
+type batchProvider struct {
+	batches [][]string
+}
+
+func (p *batchProvider) Lookup(ctx context.Context, domainName string) (Result, error) {
+	results, err := p.LookupBatch(ctx, []string{domainName})
+	if err != nil {
+		return Result{}, err
+	}
+	return results[0], nil
+}
+
+func (p *batchProvider) LookupBatch(ctx context.Context, domainNames []string) ([]Result, error) {
+	p.batches = append(p.batches, domainNames)
+	if domainNames[0] == "broken.kz" {
+		return nil, errors.New("request status error: 500")
+	}
+
+	var results []Result
+	for _, name := range domainNames {
+		results = append(results, Result{Name: name, IsAvailable: true})
+	}
+	return results, nil
+}
+
+func (p *batchProvider) MaxBatchSize() int {
+	return 2
+}
+
+func TestChecker_Check(t *testing.T) {
+	expiration := time.Now().AddDate(0, 0, 30)
+	provider := providerFunc(func(ctx context.Context, domainName string) (Result, error) {
+		if domainName == "broken.kz" {
+			return Result{}, errors.New("request status error: 500")
+		}
+		return Result{Name: domainName, ExpirationDate: &expiration}, nil
+	})
+
+	policies := map[string]Policy{
+		"example.kz": {DaysToExpire: 60},
+		"other.kz":   {DaysToExpire: 15},
+	}
+	var received []string
+
+	checker := New(provider,
+		WithPolicy(func(name string) Policy { return policies[name] }),
+		OnResult(func(result Result) { received = append(received, result.Name) }),
+	)
+
+	results, err := checker.Check(context.Background(), []string{"example.kz", "other.kz", "broken.kz"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(results) != 3 || len(received) != 3 {
+		t.Fatalf("expected 3 results, got %v (received %v)", results, received)
+	}
+	if results[0].Severity() != SeverityWarning {
+		t.Error("domain should be close to expiration with a 60 days threshold", results[0])
+	}
+	if results[1].Severity() != SeverityOk {
+		t.Error("domain should be ok with a 15 days threshold", results[1])
+	}
+	if results[2].Name != "broken.kz" || results[2].Error == nil || results[2].Severity() != SeverityError {
+		t.Error("failed lookup should be reported in the result", results[2])
+	}
+}
+
+func TestChecker_Check_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	provider := providerFunc(func(ctx context.Context, domainName string) (Result, error) {
+		cancel()
+		return Result{Name: domainName, IsAvailable: true}, nil
+	})
+
+	results, err := New(provider, WithDelay(time.Hour)).Check(ctx, []string{"first.kz", "second.kz"})
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if len(results) != 0 {
+		t.Errorf("lookup finished after cancellation should not be returned: %v", results)
+	}
+}
+
+func TestChecker_Check_CanceledDuringDelay(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*10)
+	defer cancel()
+
+	provider := providerFunc(func(ctx context.Context, domainName string) (Result, error) {
+		return Result{Name: domainName, IsAvailable: true}, nil
+	})
+
+	results, err := New(provider, WithDelay(time.Hour)).Check(ctx, []string{"first.kz", "second.kz"})
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if len(results) != 1 || results[0].Name != "first.kz" {
+		t.Errorf("expected the result received before the deadline, got %v", results)
+	}
+}
+
+func TestChecker_Check_Batches(t *testing.T) {
+	provider := &batchProvider{}
+	var received []string
+
+	results, err := New(provider, OnResult(func(result Result) { received = append(received, result.Name) })).
+		Check(context.Background(), []string{"a.kz", "b.kz", "broken.kz", "c.kz", "d.kz"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if fmt.Sprint(provider.batches) != "[[a.kz b.kz] [broken.kz c.kz] [d.kz]]" {
+		t.Errorf("unexpected batches: %v", provider.batches)
+	}
+	if strings.Join(received, ",") != "a.kz,b.kz,broken.kz,c.kz,d.kz" {
+		t.Errorf("unexpected results order: %v", received)
+	}
+	if !results[0].IsAvailable || results[2].Error == nil || results[3].Error == nil || results[3].Name != "c.kz" {
+		t.Errorf("failed batch should be reported for each of its domains: %v", results)
+	}
+}
@@ -0,0 +1,182 @@
+// Package kzdomain checks the registration of .kz domains: whether a domain is available for
+// registration and when it expires.
+//
+//	checker := kzdomain.New(&kzdomain.RDAPProvider{}, kzdomain.WithPolicy(func(string) kzdomain.Policy {
+//		return kzdomain.Policy{DaysToExpire: 30}
+//	}))
+//	results, err := checker.Check(ctx, []string{"example.kz"})
+package kzdomain
+
+import "time"
+
+// Result is the registration state of a domain.
+type Result struct {
+	Name        string
+	IsAvailable bool
+	// ExpirationDate is nil when the provider did not report it.
+	ExpirationDate *time.Time
+	// Registrar is the name of the sponsoring registrar, when the provider reports it.
+	Registrar string
+	// Error is set when the lookup failed.
+	Error error
+	// Policy is the rules the result is evaluated against.
+	Policy Policy
+}
+
+// Severity describes how urgent the domain state is. Higher values are more severe.
+type Severity int
+
+const (
+	SeverityOk Severity = iota
+	SeverityWarning
+	SeverityError
+	SeverityCritical
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	case SeverityCritical:
+		return "critical"
+	default:
+		return "ok"
+	}
+}
+
+// ParseSeverity returns the severity with the name returned by Severity.String.
+func ParseSeverity(name string) (Severity, bool) {
+	for _, severity := range []Severity{SeverityOk, SeverityWarning, SeverityError, SeverityCritical} {
+		if severity.String() == name {
+			return severity, true
+		}
+	}
+	return SeverityOk, false
+}
+
+// Status is the state of a domain that a severity is assigned to.
+type Status string
+
+const (
+	StatusOk        Status = "ok"
+	StatusExpiring  Status = "expiring"
+	StatusExpired   Status = "expired"
+	StatusAvailable Status = "available"
+	// StatusNoDate means the provider did not report the expiration date.
+	StatusNoDate Status = "no_date"
+	StatusError  Status = "error"
+)
+
+// defaultSeverities are the severities of statuses not overridden by Policy.Severities.
+var defaultSeverities = map[Status]Severity{
+	StatusOk:        SeverityOk,
+	StatusExpiring:  SeverityWarning,
+	StatusExpired:   SeverityCritical,
+	StatusAvailable: SeverityCritical,
+	StatusNoDate:    SeverityError,
+	StatusError:     SeverityError,
+}
+
+// IsValid reports whether s is one of the statuses of a result.
+func (s Status) IsValid() bool {
+	_, ok := defaultSeverities[s]
+	return ok
+}
+
+// Policy holds the rules a result is evaluated against. The zero value treats domains as close to
+// expiration on the day of expiration only, counts days in the local time zone and uses the system clock.
+type Policy struct {
+	// DaysToExpire is the number of days left from which a domain is close to expiration.
+	DaysToExpire int64
+	// OnlyErrors leaves domains that are ok out of notifications.
+	OnlyErrors bool
+	// Severities overrides the default severity of statuses, e.g. StatusAvailable: SeverityWarning
+	// for domains that are not going to be renewed.
+	Severities map[Status]Severity
+	// Location is the time zone of calendar days. Nil means the local time zone.
+	Location *time.Location
+	// Now returns the current time. Nil means time.Now.
+	Now func() time.Time
+}
+
+func (p Policy) location() *time.Location {
+	if p.Location != nil {
+		return p.Location
+	}
+	return time.Local
+}
+
+func (p Policy) now() time.Time {
+	if p.Now != nil {
+		return p.Now()
+	}
+	return time.Now()
+}
+
+// DaysLeft returns the number of calendar days in the policy time zone until the expiration date:
+// 0 on the day of expiration and negative after it, regardless of the time of the check.
+// It must only be called when ExpirationDate is set.
+func (r Result) DaysLeft() int64 {
+	location := r.Policy.location()
+	today := date(r.Policy.now().In(location))
+	expiration := date(r.ExpirationDate.In(location))
+
+	return int64(expiration.Sub(today).Hours() / 24)
+}
+
+// date returns the midnight of the calendar day of t in UTC, so that days are not affected by DST changes.
+func date(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// ExpiresAt returns the expiration date in the policy time zone.
+func (r Result) ExpiresAt() time.Time {
+	return r.ExpirationDate.In(r.Policy.location())
+}
+
+// IsCloseToExpire reports whether no more than Policy.DaysToExpire days are left.
+func (r Result) IsCloseToExpire() bool {
+	return r.DaysLeft() <= r.Policy.DaysToExpire
+}
+
+// IsExpired reports whether the expiration date has passed.
+func (r Result) IsExpired() bool {
+	return r.DaysLeft() < 0
+}
+
+// IsOk reports whether the severity of the result is SeverityOk.
+func (r Result) IsOk() bool {
+	return r.Severity() == SeverityOk
+}
+
+// Status returns the state of the domain: a failed check, available for registration, without
+// an expiration date, expired, close to expiration or ok.
+func (r Result) Status() Status {
+	switch {
+	case r.Error != nil:
+		return StatusError
+	case r.IsAvailable:
+		return StatusAvailable
+	case r.ExpirationDate == nil:
+		return StatusNoDate
+	case r.IsExpired():
+		return StatusExpired
+	case r.IsCloseToExpire():
+		return StatusExpiring
+	default:
+		return StatusOk
+	}
+}
+
+// Severity returns the severity of the status from Policy.Severities or, by default, SeverityWarning for
+// domains close to expiration, SeverityError when the check failed or the expiration date is unknown
+// and SeverityCritical for expired or available domains.
+func (r Result) Severity() Severity {
+	status := r.Status()
+	if severity, ok := r.Policy.Severities[status]; ok {
+		return severity
+	}
+	return defaultSeverities[status]
+}
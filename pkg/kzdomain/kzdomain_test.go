@@ -0,0 +1,51 @@
+package kzdomain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestResult_Severity(t *testing.T) {
+	expiring := time.Now().AddDate(0, 0, 3)
+	expired := time.Now().AddDate(0, 0, -3)
+	policy := Policy{
+		DaysToExpire: 15,
+		Severities:   map[Status]Severity{StatusAvailable: SeverityWarning, StatusNoDate: SeverityOk},
+	}
+
+	tests := []struct {
+		result   Result
+		status   Status
+		severity Severity
+	}{
+		{Result{ExpirationDate: &expiring}, StatusExpiring, SeverityWarning},
+		{Result{ExpirationDate: &expired}, StatusExpired, SeverityCritical},
+		{Result{IsAvailable: true}, StatusAvailable, SeverityWarning},
+		{Result{}, StatusNoDate, SeverityOk},
+		{Result{Error: errors.New("timeout")}, StatusError, SeverityError},
+	}
+
+	for _, test := range tests {
+		test.result.Policy = policy
+		if status := test.result.Status(); status != test.status {
+			t.Errorf("expected status %s, got %s", test.status, status)
+		}
+		if severity := test.result.Severity(); severity != test.severity {
+			t.Errorf("expected severity %s for %s, got %s", test.severity, test.status, severity)
+		}
+	}
+
+	if !(Result{Policy: policy}).IsOk() {
+		t.Error("result overridden to SeverityOk should be ok")
+	}
+}
+
+func TestParseSeverity(t *testing.T) {
+	if severity, ok := ParseSeverity("critical"); !ok || severity != SeverityCritical {
+		t.Errorf("unexpected severity: %s", severity)
+	}
+	if _, ok := ParseSeverity("fatal"); ok {
+		t.Error("unknown severity should not be parsed")
+	}
+}
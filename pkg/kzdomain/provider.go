@@ -0,0 +1,83 @@
+package kzdomain
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Provider looks up the registration data of a domain.
+type Provider interface {
+	Lookup(ctx context.Context, domainName string) (Result, error)
+}
+
+// BatchProvider is a Provider that looks up several domains in one request.
+type BatchProvider interface {
+	Provider
+	// LookupBatch returns the results in the order of domainNames. A failed lookup of a single
+	// domain is reported in Result.Error, a failed request in the returned error.
+	LookupBatch(ctx context.Context, domainNames []string) ([]Result, error)
+	// MaxBatchSize returns the maximum number of domains passed to LookupBatch.
+	MaxBatchSize() int
+}
+
+// ErrorLog receives details of failed requests, e.g. the body of an error response.
+type ErrorLog func(message string)
+
+func (l ErrorLog) write(message string) {
+	if l != nil {
+		l(message)
+	}
+}
+
+var defaultClient = &http.Client{
+	Timeout: time.Second * 10,
+}
+
+const (
+	retries       = 3
+	retryInterval = time.Second * 10
+)
+
+// retry sends the request built by newRequest, repeating it on network errors.
+func retry(ctx context.Context, client *http.Client, newRequest func(ctx context.Context) (*http.Request, error)) (*http.Response, error) {
+	if client == nil {
+		client = defaultClient
+	}
+
+	var (
+		response *http.Response
+		err      error
+	)
+
+	for attempt := 1; attempt <= retries; attempt++ {
+		var request *http.Request
+		request, err = newRequest(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		response, err = client.Do(request)
+		if err == nil {
+			break
+		}
+
+		if attempt < retries {
+			if sleepErr := sleep(ctx, retryInterval); sleepErr != nil {
+				return nil, sleepErr
+			}
+		}
+	}
+
+	return response, err
+}
+
+func bodyToString(body io.Reader) string {
+	bodyBytes := new(bytes.Buffer)
+	if _, err := bodyBytes.ReadFrom(body); err != nil {
+		return ""
+	}
+	return bodyBytes.String()
+}
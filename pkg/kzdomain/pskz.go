@@ -0,0 +1,234 @@
+package kzdomain
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// PsKzURL is the ps.kz GraphQL endpoint.
+const PsKzURL = "https://console.ps.kz/domains/graphql"
+
+// DefaultPsKzBatchSize is the number of domains PsKzProvider looks up in one request by default.
+const DefaultPsKzBatchSize = 20
+
+// whoisFields are the fields of a whois query.
+const whoisFields = `{
+				available
+				info {
+					domain {
+						exDate
+					}
+				}
+			}`
+
+// PsKzProvider fetches domain info from the ps.kz GraphQL API.
+type PsKzProvider struct {
+	// Token is the ps.kz API token.
+	Token string
+	// URL defaults to PsKzURL.
+	URL string
+	// Client defaults to a client with a 10 seconds timeout.
+	Client *http.Client
+	// ErrorLog receives the responses of failed requests. Nil discards them.
+	ErrorLog ErrorLog
+	// BatchSize is the number of domains looked up in one request. Zero means DefaultPsKzBatchSize.
+	BatchSize int
+}
+
+func (p *PsKzProvider) url() string {
+	if p.URL != "" {
+		return p.URL
+	}
+	return PsKzURL
+}
+
+// MaxBatchSize returns the number of domains looked up in one request.
+func (p *PsKzProvider) MaxBatchSize() int {
+	if p.BatchSize > 0 {
+		return p.BatchSize
+	}
+	return DefaultPsKzBatchSize
+}
+
+// Lookup queries the whois data of the domain.
+func (p *PsKzProvider) Lookup(ctx context.Context, domainName string) (Result, error) {
+	results, err := p.LookupBatch(ctx, []string{domainName})
+	if err != nil {
+		return Result{}, err
+	}
+	return results[0], results[0].Error
+}
+
+// LookupBatch queries the whois data of all domains in one request, each under its own alias.
+func (p *PsKzProvider) LookupBatch(ctx context.Context, domainNames []string) ([]Result, error) {
+	// TODO Проверка что домен .kz
+
+	var parameters, fields strings.Builder
+	variables := make(map[string]interface{}, len(domainNames))
+
+	for i, domainName := range domainNames {
+		alias := fmt.Sprintf("d%d", i)
+		variables[alias] = domainName
+
+		if i > 0 {
+			parameters.WriteString(", ")
+		}
+		fmt.Fprintf(&parameters, "$%s: String!", alias)
+		fmt.Fprintf(&fields, "\t\t\t%s: whois(domain: $%s) %s\n", alias, alias, whoisFields)
+	}
+
+	query := fmt.Sprintf(`query(%s) {
+	domains {
+		whois {
+%s		}
+	}
+}`, parameters.String(), fields.String())
+
+	var response GraphQLResponse
+	if err := p.query(ctx, GraphQLRequest{Query: query, Variables: variables}, &response); err != nil {
+		return nil, err
+	}
+
+	results := make([]Result, 0, len(domainNames))
+	for i, domainName := range domainNames {
+		alias := fmt.Sprintf("d%d", i)
+		results = append(results, whoisResult(domainName, response.Whois(alias), response.Error(alias)))
+	}
+
+	return results, nil
+}
+
+func whoisResult(domainName string, whois *Whois, errorMessage string) Result {
+	if whois == nil {
+		if errorMessage == "" {
+			errorMessage = "no whois data in response"
+		}
+		return Result{Name: domainName, Error: fmt.Errorf("ps.kz error: %s", errorMessage)}
+	}
+
+	var datePointer *time.Time
+	date, err := time.Parse(time.RFC3339, whois.GetExpirationDate())
+
+	if err != nil {
+		datePointer = nil
+	} else {
+		datePointer = &date
+	}
+
+	return Result{
+		Name:           domainName,
+		IsAvailable:    whois.IsAvailable(),
+		ExpirationDate: datePointer,
+	}
+}
+
+// query sends the GraphQL request and decodes the response into out.
+func (p *PsKzProvider) query(ctx context.Context, query GraphQLRequest, out interface{}) error {
+	jsonBody, err := json.Marshal(query)
+	if err != nil {
+		return err
+	}
+
+	response, err := retry(ctx, p.Client, func(ctx context.Context) (*http.Request, error) {
+		request, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url(), bytes.NewReader(jsonBody))
+		if err != nil {
+			return nil, err
+		}
+		request.Header.Set("Content-Type", "application/json")
+		request.Header.Set("X-User-Token", p.Token)
+		return request, nil
+	})
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != 200 {
+		p.ErrorLog.write(fmt.Sprintf("Request status error: %d, Body: %s", response.StatusCode, bodyToString(response.Body)))
+
+		return fmt.Errorf("request status error: %d", response.StatusCode)
+	}
+
+	err = json.NewDecoder(response.Body).Decode(out)
+	if err != nil {
+		return fmt.Errorf("failed to parse JSON response: %s", err.Error())
+	}
+
+	return nil
+}
+
+// Account returns the balance of the ps.kz account of the token and the domains registered in it.
+func (p *PsKzProvider) Account(ctx context.Context) (Account, error) {
+	query := `query {
+	account {
+		balance
+	}
+	domains {
+		domain {
+			list {
+				name
+				exDate
+				autoRenew
+				renewPrice
+			}
+		}
+	}
+}`
+
+	var response AccountResponse
+	if err := p.query(ctx, GraphQLRequest{Query: query}, &response); err != nil {
+		return Account{}, err
+	}
+	if len(response.Errors) > 0 {
+		return Account{}, fmt.Errorf("ps.kz error: %s", response.Errors[0].Message)
+	}
+
+	account := Account{Balance: response.Data.Account.Balance}
+	for _, domain := range response.Data.Domains.Domain.List {
+		accountDomain := AccountDomain{
+			Name:         domain.Name,
+			AutoRenew:    domain.AutoRenew,
+			RenewalPrice: domain.RenewPrice,
+		}
+		if date, err := time.Parse(time.RFC3339, domain.ExDate); err == nil {
+			accountDomain.ExpirationDate = &date
+		}
+		account.Domains = append(account.Domains, accountDomain)
+	}
+
+	return account, nil
+}
+
+// AccountDomains returns the names of the domains registered in the ps.kz account of the token.
+// Unlike Account it does not query the balance, so it works for tokens without access to billing.
+func (p *PsKzProvider) AccountDomains(ctx context.Context) ([]string, error) {
+	query := `query {
+	domains {
+		domain {
+			list {
+				name
+			}
+		}
+	}
+}`
+
+	var response AccountDomainsResponse
+	if err := p.query(ctx, GraphQLRequest{Query: query}, &response); err != nil {
+		return nil, err
+	}
+	if len(response.Errors) > 0 {
+		return nil, fmt.Errorf("ps.kz error: %s", response.Errors[0].Message)
+	}
+
+	names := make([]string, 0, len(response.Data.Domains.Domain.List))
+	for _, domain := range response.Data.Domains.Domain.List {
+		names = append(names, domain.Name)
+	}
+
+	return names, nil
+}
@@ -0,0 +1,124 @@
+package kzdomain
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestPsKzProvider_LookupBatch(t *testing.T) {
+	var request GraphQLRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-User-Token") != "token" {
+			t.Errorf("unexpected token: %q", r.Header.Get("X-User-Token"))
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Fatal(err)
+		}
+
+		w.Write([]byte(`{"data":{"domains":{"whois":{
+			"d0":{"available":false,"info":{"domain":{"exDate":"2030-01-02T00:00:00Z"}}},
+			"d1":{"available":true,"info":{"domain":{"exDate":""}}},
+			"d2":null
+		}}},"errors":[{"message":"Invalid domain","path":["domains","whois","d2"]}]}`))
+	}))
+	defer server.Close()
+
+	provider := &PsKzProvider{Token: "token", URL: server.URL}
+	results, err := provider.LookupBatch(context.Background(), []string{"example.kz", "free.kz", `"bad".kz`})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if request.Variables["d0"] != "example.kz" || request.Variables["d2"] != `"bad".kz` {
+		t.Errorf("domains should be passed as variables: %v", request.Variables)
+	}
+	if strings.Contains(request.Query, "example.kz") {
+		t.Errorf("domain should not be spliced into the query: %s", request.Query)
+	}
+	for _, part := range []string{"query($d0: String!, $d1: String!, $d2: String!)", "d1: whois(domain: $d1)"} {
+		if !strings.Contains(request.Query, part) {
+			t.Errorf("query should contain %q: %s", part, request.Query)
+		}
+	}
+
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %v", results)
+	}
+	if results[0].Name != "example.kz" || results[0].ExpirationDate == nil || results[0].ExpirationDate.Year() != 2030 {
+		t.Error("unexpected result", results[0])
+	}
+	if !results[1].IsAvailable || results[1].ExpirationDate != nil {
+		t.Error("unexpected result", results[1])
+	}
+	if results[2].Error == nil || results[2].Error.Error() != "ps.kz error: Invalid domain" {
+		t.Error("unexpected result", results[2])
+	}
+}
+
+func TestPsKzProvider_Lookup_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data":null,"errors":[{"message":"Unauthorized"}]}`))
+	}))
+	defer server.Close()
+
+	provider := &PsKzProvider{URL: server.URL}
+	_, err := provider.Lookup(context.Background(), "example.kz")
+
+	if err == nil || err.Error() != "ps.kz error: Unauthorized" {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestPsKzProvider_Account(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data":{"account":{"balance":1500.5},"domains":{"domain":{"list":[
+			{"name":"example.kz","exDate":"2030-01-02T00:00:00Z","autoRenew":true,"renewPrice":5000},
+			{"name":"пример.қаз","exDate":"","autoRenew":false,"renewPrice":6000}
+		]}}}}`))
+	}))
+	defer server.Close()
+
+	provider := &PsKzProvider{URL: server.URL}
+	account, err := provider.Account(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if account.Balance != 1500.5 || len(account.Domains) != 2 {
+		t.Fatalf("unexpected account: %+v", account)
+	}
+	if domain := account.Domains[0]; !domain.AutoRenew || domain.RenewalPrice != 5000 || domain.ExpirationDate == nil {
+		t.Errorf("unexpected domain: %+v", domain)
+	}
+	if account.Domains[1].ExpirationDate != nil {
+		t.Errorf("unexpected domain: %+v", account.Domains[1])
+	}
+
+}
+
+func TestPsKzProvider_AccountDomains(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request GraphQLRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(request.Query, "balance") || strings.Contains(request.Query, "renewPrice") {
+			t.Errorf("names-only query expected, got %s", request.Query)
+		}
+		w.Write([]byte(`{"data":{"domains":{"domain":{"list":[{"name":"example.kz"},{"name":"пример.қаз"}]}}}}`))
+	}))
+	defer server.Close()
+
+	provider := &PsKzProvider{URL: server.URL}
+	names, err := provider.AccountDomains(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(names, ",") != "example.kz,пример.қаз" {
+		t.Errorf("unexpected domains: %v", names)
+	}
+}
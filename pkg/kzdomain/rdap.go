@@ -0,0 +1,155 @@
+package kzdomain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// RDAPResponse represents the RDAP API response from nic.kz.
+type RDAPResponse struct {
+	LdhName  string       `json:"ldhName"`
+	Status   []string     `json:"status"`
+	Events   []RDAPEvent  `json:"events"`
+	Entities []RDAPEntity `json:"entities"`
+}
+
+// RDAPEntity represents a contact of the domain, e.g. its registrar.
+type RDAPEntity struct {
+	Roles      []string `json:"roles"`
+	VCardArray []any    `json:"vcardArray"`
+}
+
+// RDAPEvent represents a single event in the RDAP response.
+type RDAPEvent struct {
+	Action string `json:"eventAction"`
+	Date   string `json:"eventDate"`
+}
+
+// GetExpirationDate returns the expiration date string from RDAP events.
+func (r RDAPResponse) GetExpirationDate() string {
+	for _, e := range r.Events {
+		if e.Action == "expiration" {
+			return e.Date
+		}
+	}
+	return ""
+}
+
+// GetRegistrar returns the formatted name (vCard "fn") of the registrar entity.
+func (r RDAPResponse) GetRegistrar() string {
+	for _, entity := range r.Entities {
+		for _, role := range entity.Roles {
+			if role == "registrar" {
+				return entity.formattedName()
+			}
+		}
+	}
+	return ""
+}
+
+// formattedName extracts "fn" from a jCard: ["vcard", [["fn", {}, "text", "Name"], ...]].
+func (e RDAPEntity) formattedName() string {
+	if len(e.VCardArray) < 2 {
+		return ""
+	}
+	properties, _ := e.VCardArray[1].([]any)
+	for _, property := range properties {
+		values, _ := property.([]any)
+		if len(values) < 4 || values[0] != "fn" {
+			continue
+		}
+		name, _ := values[3].(string)
+		return name
+	}
+	return ""
+}
+
+const rdapDateLayout = "2006-01-02 15:04:05 -07:00"
+
+// parseRDAPDate parses dates in the format "2031-07-14 06:47:20 (GMT+0:00)".
+// Standard RFC 3339 dates (RFC 9083) are accepted as well.
+func parseRDAPDate(s string) (time.Time, error) {
+	if date, err := time.Parse(time.RFC3339, s); err == nil {
+		return date, nil
+	}
+
+	if idx := strings.Index(s, " (GMT"); idx != -1 {
+		tz := s[idx+5 : len(s)-1] // e.g. "+0:00"
+		// Normalize single-digit hour offset: "+0:00" -> "+00:00"
+		if len(tz) > 2 && tz[2] == ':' {
+			tz = tz[:1] + "0" + tz[1:]
+		}
+		return time.Parse(rdapDateLayout, s[:idx]+" "+tz)
+	}
+	return time.Parse(rdapDateLayout, s)
+}
+
+// RDAPURL is the nic.kz RDAP endpoint.
+const RDAPURL = "https://rdap.nic.kz"
+
+// RDAPProvider fetches domain info from the nic.kz RDAP endpoint.
+type RDAPProvider struct {
+	// BaseURL defaults to RDAPURL.
+	BaseURL string
+	// Client defaults to a client with a 10 seconds timeout.
+	Client *http.Client
+	// ErrorLog receives the responses of failed requests. Nil discards them.
+	ErrorLog ErrorLog
+}
+
+func (p *RDAPProvider) baseURL() string {
+	if p.BaseURL != "" {
+		return p.BaseURL
+	}
+	return RDAPURL
+}
+
+// Lookup requests the RDAP domain object. A domain that is not found is available for registration.
+func (p *RDAPProvider) Lookup(ctx context.Context, domainName string) (Result, error) {
+	url := fmt.Sprintf("%s/domain/%s", p.baseURL(), domainName)
+
+	resp, err := retry(ctx, p.Client, func(ctx context.Context) (*http.Request, error) {
+		return http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	})
+	if err != nil {
+		return Result{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return Result{Name: domainName, IsAvailable: true}, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		p.ErrorLog.write(fmt.Sprintf("RDAP request status error: %d, Body: %s", resp.StatusCode, bodyToString(resp.Body)))
+		return Result{}, fmt.Errorf("RDAP request status error: %d", resp.StatusCode)
+	}
+
+	var rdapResp RDAPResponse
+	if err := json.NewDecoder(resp.Body).Decode(&rdapResp); err != nil {
+		return Result{}, fmt.Errorf("failed to parse RDAP JSON response: %s", err.Error())
+	}
+
+	var datePointer *time.Time
+
+	dateStr := rdapResp.GetExpirationDate()
+
+	date, err := parseRDAPDate(dateStr)
+
+	if err != nil {
+		datePointer = nil
+	} else {
+		datePointer = &date
+	}
+
+	return Result{
+		Name:           domainName,
+		IsAvailable:    false,
+		ExpirationDate: datePointer,
+		Registrar:      rdapResp.GetRegistrar(),
+	}, nil
+}
@@ -1,9 +1,9 @@
-package api
+package kzdomain
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"kz-domain-monitor/internal/config"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -31,6 +31,27 @@ func TestRDAPResponse_GetExpirationDate(t *testing.T) {
 	}
 }
 
+func TestParseRDAPDate(t *testing.T) {
+	// 2031-07-14T06:47:20Z
+	const expected = 1941778040
+
+	for _, input := range []string{
+		"2031-07-14 06:47:20 (GMT+0:00)",
+		"2031-07-14 12:47:20 (GMT+6:00)",
+		"2031-07-14T06:47:20Z",
+		"2031-07-14T12:47:20+06:00",
+	} {
+		date, err := parseRDAPDate(input)
+		if err != nil {
+			t.Errorf("%s: %v", input, err)
+			continue
+		}
+		if date.Unix() != expected {
+			t.Errorf("%s: expected %d, got %d", input, expected, date.Unix())
+		}
+	}
+}
+
 func TestRDAPResponse_GetExpirationDate_Missing(t *testing.T) {
 	resp := RDAPResponse{
 		Events: []RDAPEvent{
@@ -43,7 +64,7 @@ func TestRDAPResponse_GetExpirationDate_Missing(t *testing.T) {
 	}
 }
 
-func TestRDAPProvider_GetDomainInfo_Success(t *testing.T) {
+func TestRDAPProvider_Lookup_Success(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/rdap+json")
 		fmt.Fprint(w, `{
@@ -57,10 +78,10 @@ func TestRDAPProvider_GetDomainInfo_Success(t *testing.T) {
 	defer server.Close()
 
 	provider := &RDAPProvider{BaseURL: server.URL}
-	domain := provider.GetDomainInfo("example.kz")
+	domain, err := provider.Lookup(context.Background(), "example.kz")
 
-	if domain.Error != nil {
-		t.Fatalf("unexpected error: %v", domain.Error)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
 	}
 	if domain.IsAvailable {
 		t.Error("domain should not be available")
@@ -70,38 +91,38 @@ func TestRDAPProvider_GetDomainInfo_Success(t *testing.T) {
 	}
 }
 
-func TestRDAPProvider_GetDomainInfo_Available(t *testing.T) {
+func TestRDAPProvider_Lookup_Available(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		http.NotFound(w, r)
 	}))
 	defer server.Close()
 
 	provider := &RDAPProvider{BaseURL: server.URL}
-	domain := provider.GetDomainInfo("available.kz")
+	domain, err := provider.Lookup(context.Background(), "available.kz")
 
-	if domain.Error != nil {
-		t.Fatalf("unexpected error: %v", domain.Error)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
 	}
 	if !domain.IsAvailable {
 		t.Error("domain should be available (404 response)")
 	}
 }
 
-func TestRDAPProvider_GetDomainInfo_ServerError(t *testing.T) {
+func TestRDAPProvider_Lookup_ServerError(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "internal server error", http.StatusInternalServerError)
 	}))
 	defer server.Close()
 
 	provider := &RDAPProvider{BaseURL: server.URL}
-	domain := provider.GetDomainInfo("error.kz")
+	domain, err := provider.Lookup(context.Background(), "error.kz")
 
-	if domain.Error == nil {
-		t.Error("expected error for 500 response")
+	if err == nil {
+		t.Error("expected error for 500 response", domain)
 	}
 }
 
-func TestRDAPProvider_GetDomainInfo_InvalidJSON(t *testing.T) {
+func TestRDAPProvider_Lookup_InvalidJSON(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/rdap+json")
 		fmt.Fprint(w, `invalid json`)
@@ -109,33 +130,28 @@ func TestRDAPProvider_GetDomainInfo_InvalidJSON(t *testing.T) {
 	defer server.Close()
 
 	provider := &RDAPProvider{BaseURL: server.URL}
-	domain := provider.GetDomainInfo("example.kz")
+	domain, err := provider.Lookup(context.Background(), "example.kz")
 
-	if domain.Error == nil {
-		t.Error("expected error for invalid JSON response")
+	if err == nil {
+		t.Error("expected error for invalid JSON response", domain)
 	}
 }
 
-func TestNewProvider_RDAP(t *testing.T) {
-	cfg := config.Config{DomainProvider: "rdap"}
-	provider := NewProvider(cfg)
-	if _, ok := provider.(*RDAPProvider); !ok {
-		t.Error("expected RDAPProvider for 'rdap' config")
-	}
-}
+func TestRDAPResponse_GetRegistrar(t *testing.T) {
+	var resp RDAPResponse
+	jsonString := `{
+		"ldhName": "example.kz",
+		"entities": [
+			{"roles": ["registrant"], "vcardArray": ["vcard", [["version", {}, "text", "4.0"], ["fn", {}, "text", "Owner"]]]},
+			{"roles": ["registrar"], "vcardArray": ["vcard", [["version", {}, "text", "4.0"], ["fn", {}, "text", "PS Internet Company"]]]}
+		]
+	}`
 
-func TestNewProvider_PsKz(t *testing.T) {
-	cfg := config.Config{DomainProvider: "pskz"}
-	provider := NewProvider(cfg)
-	if _, ok := provider.(*PsKzProvider); !ok {
-		t.Error("expected PsKzProvider for 'pskz' config")
+	if err := json.Unmarshal([]byte(jsonString), &resp); err != nil {
+		t.Fatal(err)
 	}
-}
 
-func TestNewProvider_Default(t *testing.T) {
-	cfg := config.Config{DomainProvider: ""}
-	provider := NewProvider(cfg)
-	if _, ok := provider.(*PsKzProvider); !ok {
-		t.Error("expected PsKzProvider as default when DomainProvider is empty")
+	if got := resp.GetRegistrar(); got != "PS Internet Company" {
+		t.Errorf("expected PS Internet Company, got %q", got)
 	}
 }
@@ -0,0 +1,90 @@
+package kzdomain
+
+type GraphQLRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables,omitempty"`
+}
+
+type GraphQLResponse struct {
+	Data struct {
+		Domains struct {
+			// Whois holds the whois query results by alias. A failed query is nil.
+			Whois map[string]*Whois `json:"whois"`
+		} `json:"domains"`
+	} `json:"data"`
+	Errors []GraphQLError `json:"errors"`
+}
+
+type AccountDomainsResponse struct {
+	Data struct {
+		Domains struct {
+			Domain struct {
+				List []struct {
+					Name string `json:"name"`
+				} `json:"list"`
+			} `json:"domain"`
+		} `json:"domains"`
+	} `json:"data"`
+	Errors []GraphQLError `json:"errors"`
+}
+
+type AccountResponse struct {
+	Data struct {
+		Account struct {
+			Balance float64 `json:"balance"`
+		} `json:"account"`
+		Domains struct {
+			Domain struct {
+				List []struct {
+					Name       string  `json:"name"`
+					ExDate     string  `json:"exDate"`
+					AutoRenew  bool    `json:"autoRenew"`
+					RenewPrice float64 `json:"renewPrice"`
+				} `json:"list"`
+			} `json:"domain"`
+		} `json:"domains"`
+	} `json:"data"`
+	Errors []GraphQLError `json:"errors"`
+}
+
+type GraphQLError struct {
+	Message string `json:"message"`
+	// Path is the path of the failed field, e.g. ["domains", "whois", "d0"].
+	Path []interface{} `json:"path"`
+}
+
+type Whois struct {
+	Available bool `json:"available"`
+	Info      struct {
+		Domain struct {
+			ExDate string `json:"exDate"`
+		} `json:"domain"`
+	} `json:"info"`
+}
+
+// Whois returns the whois query result by alias, or nil when the query failed.
+func (r GraphQLResponse) Whois(alias string) *Whois {
+	return r.Data.Domains.Whois[alias]
+}
+
+// Error returns the message of the error of the field with the alias, or of the first error that has
+// no path, e.g. a failed authentication.
+func (r GraphQLResponse) Error(alias string) string {
+	for _, err := range r.Errors {
+		if len(err.Path) == 0 {
+			return err.Message
+		}
+		if last, ok := err.Path[len(err.Path)-1].(string); ok && last == alias {
+			return err.Message
+		}
+	}
+	return ""
+}
+
+func (w Whois) IsAvailable() bool {
+	return w.Available
+}
+
+func (w Whois) GetExpirationDate() string {
+	return w.Info.Domain.ExDate
+}
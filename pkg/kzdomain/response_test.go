@@ -0,0 +1,49 @@
+package kzdomain
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	var response GraphQLResponse
+	jsonString := `{"data":{"domains":{"whois":{"d0":{"available":true,"info":{"domain":{"exDate":"2006-01-02T15:04:05Z07:00"}}}}}}}`
+
+	err := json.Unmarshal([]byte(jsonString), &response)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	whois := response.Whois("d0")
+	if whois == nil {
+		t.Fatal("Whois d0 should be set")
+	}
+
+	if !whois.IsAvailable() {
+		t.Error("IsAvailable should be true")
+	}
+
+	if whois.GetExpirationDate() != "2006-01-02T15:04:05Z07:00" {
+		t.Error("GetExpirationDate should be 2006-01-02T15:04:05Z07:00")
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	var response GraphQLResponse
+	jsonString := `{"data":{"domains":{"whois":{"d0":null,"d1":{"available":true}}}},"errors":[{"message":"Domain not supported","path":["domains","whois","d0"]}]}`
+
+	if err := json.Unmarshal([]byte(jsonString), &response); err != nil {
+		t.Fatal(err)
+	}
+
+	if response.Whois("d0") != nil {
+		t.Error("Whois d0 should be nil")
+	}
+	if response.Error("d0") != "Domain not supported" {
+		t.Errorf("unexpected error of d0: %q", response.Error("d0"))
+	}
+	if response.Error("d1") != "" {
+		t.Errorf("unexpected error of d1: %q", response.Error("d1"))
+	}
+}